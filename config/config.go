@@ -15,15 +15,83 @@
 package config
 
 import (
+	"errors"
 	"flag"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/peterbourgon/ff/v3"
 	"github.com/rode/rode/common"
 )
 
+var noteNamePattern = regexp.MustCompile(`^projects/[A-Za-z0-9_-]+/notes/[A-Za-z0-9_.-]+$`)
+
+// TimestampPolicy controls what happens to a build timestamp that's missing or inconsistent
+type TimestampPolicy string
+
+const (
+	// TimestampPolicyReject fails the request
+	TimestampPolicyReject TimestampPolicy = "reject"
+	// TimestampPolicyClamp moves the timestamp to the nearest valid time
+	TimestampPolicyClamp TimestampPolicy = "clamp"
+	// TimestampPolicyDefault replaces the timestamp with the current time
+	TimestampPolicyDefault TimestampPolicy = "default"
+)
+
+// SlsaVersion selects the SLSA provenance predicate generated for each build
+type SlsaVersion string
+
+const (
+	// SlsaVersionNone disables SLSA provenance
+	SlsaVersionNone SlsaVersion = "none"
+	// SlsaVersionV02 generates https://slsa.dev/provenance/v0.2 predicates
+	SlsaVersionV02 SlsaVersion = "v0.2"
+	// SlsaVersionV1 generates https://slsa.dev/provenance/v1 predicates
+	SlsaVersionV1 SlsaVersion = "v1"
+)
+
+// DefaultSlsaBuildType is the buildType of SLSA provenance for builds reported to the collector
+const DefaultSlsaBuildType = "https://github.com/rode/collector-build/generic@v1"
+
+// LegacyNoteName is the note that build occurrences referenced before the collector registered its own note with Rode.
+// Occurrences that reference it are still read, but new occurrences never do.
+const LegacyNoteName = "projects/rode/notes/build_collector"
+
+// DefaultRodeMaxMessageSize is the largest message, in bytes, that Rode accepts by default, which is gRPC's default receive limit
+const DefaultRodeMaxMessageSize = 4 << 20
+
+// SbomMessageOverhead is the room, in bytes, left for the rest of the request or occurrence that carries an SBOM document
+const SbomMessageOverhead = 64 << 10
+
+// DefaultMaxSbomSize is the largest SBOM document, in bytes, that can be attached to a build by default.
+// SBOMs are sent to Rode in a single message, so the limit has to fit within the message limit Rode enforces.
+const DefaultMaxSbomSize = DefaultRodeMaxMessageSize - SbomMessageOverhead
+
 type Config struct {
-	Port         int
-	Debug        bool
-	ClientConfig *common.ClientConfig
+	Port                    int
+	Debug                   bool
+	StrictArtifactMatching  bool
+	ProjectId               string
+	NoteId                  string
+	NamedNotes              map[string]string
+	RedactQueryParams       []string
+	RedactPatterns          []*regexp.Regexp
+	BuildStartPolicy        TimestampPolicy
+	BuildEndPolicy          TimestampPolicy
+	MaxClockSkew            time.Duration
+	MaxBuildDuration        time.Duration
+	ValidationRules         []*ValidationRule
+	SlsaVersion             SlsaVersion
+	SlsaBuildType           string
+	TrustedKeys             []*TrustedKey
+	RequireSignedProvenance bool
+	SigningKey              *SigningKey
+	MaxSbomSize             int
+	RodeMaxMessageSize      int
+	ClientConfig            *common.ClientConfig
 }
 
 func Build(name string, args []string) (*Config, error) {
@@ -35,11 +103,210 @@ func Build(name string, args []string) (*Config, error) {
 
 	flags.IntVar(&c.Port, "port", 8082, "the port that the build collector's gRPC/HTTP server should listen on")
 	flags.BoolVar(&c.Debug, "debug", false, "when set, debug mode will be enabled")
+	flags.StringVar(&c.ProjectId, "project-id", "projects/rode", "the project that build notes belong to")
+	flags.StringVar(&c.NoteId, "note-id", "build_collector-build", "the id of the note that build occurrences reference by default")
+	namedNotes := flags.String("named-notes", "", "comma-separated list of name=noteId pairs for additional notes that requests can select by name")
+	flags.BoolVar(&c.StrictArtifactMatching, "strict-artifact-matching", false, "when set, artifact updates that match more than one build occurrence will be rejected instead of updating the earliest")
+	redactQueryParams := flags.String("redact-query-params", "", "comma-separated list of additional url query parameters whose values should be redacted from requests")
+	flags.Func("redact-pattern", "a regular expression whose matches should be redacted from urls in requests, can be specified more than once", func(value string) error {
+		pattern, err := regexp.Compile(value)
+		if err != nil {
+			return err
+		}
+
+		c.RedactPatterns = append(c.RedactPatterns, pattern)
+		return nil
+	})
+
+	buildStartPolicy := flags.String("build-start-policy", string(TimestampPolicyDefault), "what to do with a missing or invalid build start time: reject, clamp or default")
+	buildEndPolicy := flags.String("build-end-policy", string(TimestampPolicyDefault), "what to do with a missing or invalid build end time: reject, clamp or default")
+	flags.DurationVar(&c.MaxClockSkew, "max-clock-skew", 5*time.Minute, "how far in the future build timestamps may be, 0 disables the check")
+	flags.DurationVar(&c.MaxBuildDuration, "max-build-duration", 0, "the longest a build may take, 0 disables the check")
+	validationRulesFile := flags.String("validation-rules-file", "", "path to a JSON file of additional validation rules for build requests")
+	slsaVersion := flags.String("slsa-version", string(SlsaVersionV1), "the SLSA provenance version to record for each build: v0.2, v1 or none")
+	flags.StringVar(&c.SlsaBuildType, "slsa-build-type", DefaultSlsaBuildType, "the buildType of generated SLSA provenance")
+	var trustedKeyFiles []string
+	flags.Func("trusted-key-file", "path to a PEM encoded public key that ingested provenance signatures are verified against, can be specified more than once", func(value string) error {
+		trustedKeyFiles = append(trustedKeyFiles, value)
+		return nil
+	})
+	trustedKeysDir := flags.String("trusted-keys-dir", "", "path to a directory of PEM encoded public keys that ingested provenance signatures are verified against")
+	flags.BoolVar(&c.RequireSignedProvenance, "require-signed-provenance", false, "when set, builds and their artifacts can only be recorded by ingesting provenance in a DSSE envelope signed by a trusted key")
+	signingKeyFile := flags.String("signing-key-file", "", "path to a PEM encoded Ed25519 or ECDSA P-256 private key that the collector signs build occurrences with")
+	signingKeyId := flags.String("signing-key-id", "", "the id recorded with build occurrence signatures, defaults to the name of the signing key file without its extension")
+	flags.IntVar(&c.MaxSbomSize, "max-sbom-size", DefaultMaxSbomSize, "the largest SBOM document in bytes that can be attached to a build")
+	flags.IntVar(&c.RodeMaxMessageSize, "rode-max-message-size", DefaultRodeMaxMessageSize, "the largest message in bytes that Rode accepts, only raise it along with Rode's own limit")
 
 	err := ff.Parse(flags, args, ff.WithEnvVarNoPrefix())
 	if err != nil {
 		return nil, err
 	}
 
+	c.NamedNotes, err = parseNamedNotes(*namedNotes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, param := range strings.Split(*redactQueryParams, ",") {
+		if param = strings.TrimSpace(param); param != "" {
+			c.RedactQueryParams = append(c.RedactQueryParams, param)
+		}
+	}
+
+	if c.BuildStartPolicy, err = parseTimestampPolicy(*buildStartPolicy); err != nil {
+		return nil, err
+	}
+
+	if c.BuildEndPolicy, err = parseTimestampPolicy(*buildEndPolicy); err != nil {
+		return nil, err
+	}
+
+	if c.SlsaVersion, err = parseSlsaVersion(*slsaVersion); err != nil {
+		return nil, err
+	}
+
+	if c.SlsaVersion != SlsaVersionNone && c.SlsaBuildType == "" {
+		return nil, errors.New("slsa build type must be specified")
+	}
+
+	if c.MaxClockSkew < 0 || c.MaxBuildDuration < 0 {
+		return nil, errors.New("max clock skew and max build duration must not be negative")
+	}
+
+	if c.MaxSbomSize <= 0 {
+		return nil, errors.New("max sbom size must be positive")
+	}
+
+	if c.MaxSbomSize+SbomMessageOverhead > c.RodeMaxMessageSize {
+		return nil, fmt.Errorf("max sbom size must leave %d bytes of the %d byte Rode message limit for the rest of the occurrence", SbomMessageOverhead, c.RodeMaxMessageSize)
+	}
+
+	if *validationRulesFile != "" {
+		if c.ValidationRules, err = loadValidationRules(*validationRulesFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(trustedKeyFiles) != 0 || *trustedKeysDir != "" {
+		if c.TrustedKeys, err = loadTrustedKeys(trustedKeyFiles, *trustedKeysDir); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.RequireSignedProvenance && len(c.TrustedKeys) == 0 {
+		return nil, errors.New("at least one trusted key is required to verify signed provenance")
+	}
+
+	if *signingKeyFile != "" {
+		if c.SigningKey, err = loadSigningKey(*signingKeyFile, *signingKeyId); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, noteName := range c.NoteNames() {
+		if !noteNamePattern.MatchString(noteName) {
+			return nil, fmt.Errorf("invalid note name %q, expected format projects/{project}/notes/{note}", noteName)
+		}
+	}
+
 	return c, nil
 }
+
+// NoteName resolves the full name of the note selected by name, or the default note when name is empty
+func (c *Config) NoteName(name string) (string, error) {
+	noteId := c.NoteId
+	if name != "" {
+		var ok bool
+		if noteId, ok = c.NamedNotes[name]; !ok {
+			return "", fmt.Errorf("unknown note %q", name)
+		}
+	}
+
+	return fmt.Sprintf("%s/notes/%s", c.ProjectId, noteId), nil
+}
+
+// NoteNames returns the full names of the default note and any named notes, in a stable order
+func (c *Config) NoteNames() []string {
+	defaultNoteName, _ := c.NoteName("")
+	noteNames := []string{defaultNoteName}
+
+	var names []string
+	for name := range c.NamedNotes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	seen := map[string]bool{defaultNoteName: true}
+	for _, name := range names {
+		noteName, _ := c.NoteName(name)
+		if !seen[noteName] {
+			seen[noteName] = true
+			noteNames = append(noteNames, noteName)
+		}
+	}
+
+	return noteNames
+}
+
+// MaxRequestSize is the largest request, in bytes, that the collector accepts. It's never lower than gRPC's default
+// receive limit, and is raised when needed so that an SBOM up to the size limit can be attached in a single request.
+func (c *Config) MaxRequestSize() int {
+	if size := c.MaxSbomSize + SbomMessageOverhead; size > DefaultRodeMaxMessageSize {
+		return size
+	}
+
+	return DefaultRodeMaxMessageSize
+}
+
+// ReadNoteNames returns the full names of the notes whose build occurrences are read, which are the configured notes
+// followed by the legacy note
+func (c *Config) ReadNoteNames() []string {
+	noteNames := c.NoteNames()
+	for _, noteName := range noteNames {
+		if noteName == LegacyNoteName {
+			return noteNames
+		}
+	}
+
+	return append(noteNames, LegacyNoteName)
+}
+
+func parseNamedNotes(value string) (map[string]string, error) {
+	namedNotes := map[string]string{}
+	if value == "" {
+		return namedNotes, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid named note %q, expected name=noteId", pair)
+		}
+
+		if _, ok := namedNotes[parts[0]]; ok {
+			return nil, fmt.Errorf("note name %q specified more than once", parts[0])
+		}
+
+		namedNotes[parts[0]] = parts[1]
+	}
+
+	return namedNotes, nil
+}
+
+func parseTimestampPolicy(value string) (TimestampPolicy, error) {
+	switch policy := TimestampPolicy(value); policy {
+	case TimestampPolicyReject, TimestampPolicyClamp, TimestampPolicyDefault:
+		return policy, nil
+	}
+
+	return "", fmt.Errorf("invalid timestamp policy %q, expected reject, clamp or default", value)
+}
+
+func parseSlsaVersion(value string) (SlsaVersion, error) {
+	switch version := SlsaVersion(value); version {
+	case SlsaVersionNone, SlsaVersionV02, SlsaVersionV1:
+		return version, nil
+	}
+
+	return "", fmt.Errorf("invalid slsa version %q, expected v0.2, v1 or none", value)
+}
@@ -15,6 +15,9 @@
 package config
 
 import (
+	"regexp"
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	"github.com/rode/rode/common"
@@ -33,6 +36,24 @@ var _ = Describe("Config", func() {
 			Entry("bad grpc port", []string{"--grpc-port=foo"}),
 			Entry("bad http port", []string{"--http-port=bar"}),
 			Entry("bad debug", []string{"--debug=baz"}),
+			Entry("bad strict artifact matching", []string{"--strict-artifact-matching=qux"}),
+			Entry("malformed named notes", []string{"--named-notes=release"}),
+			Entry("duplicate named notes", []string{"--named-notes=release=foo,release=bar"}),
+			Entry("invalid project id", []string{"--project-id=rode"}),
+			Entry("invalid note id", []string{"--note-id=build/collector"}),
+			Entry("invalid named note id", []string{"--named-notes=release=build collector"}),
+			Entry("invalid redact pattern", []string{"--redact-pattern=token=("}),
+			Entry("invalid build start policy", []string{"--build-start-policy=ignore"}),
+			Entry("invalid build end policy", []string{"--build-end-policy=ignore"}),
+			Entry("negative clock skew", []string{"--max-clock-skew=-1m"}),
+			Entry("bad build duration", []string{"--max-build-duration=forever"}),
+			Entry("invalid slsa version", []string{"--slsa-version=v0.1"}),
+			Entry("empty slsa build type", []string{"--slsa-build-type="}),
+			Entry("missing trusted key file", []string{"--trusted-key-file=/does/not/exist.pem"}),
+			Entry("missing trusted keys dir", []string{"--trusted-keys-dir=/does/not/exist"}),
+			Entry("signed provenance required without trusted keys", []string{"--require-signed-provenance"}),
+			Entry("zero max sbom size", []string{"--max-sbom-size=0"}),
+			Entry("max sbom size over the Rode message limit", []string{"--max-sbom-size=4194304"}),
 		)
 
 		DescribeTable("successful configuration", func(flags []string, expected interface{}) {
@@ -42,8 +63,18 @@ var _ = Describe("Config", func() {
 			Expect(c).To(Equal(expected))
 		},
 			Entry("default config", []string{}, &Config{
-				Port:  8082,
-				Debug: false,
+				Port:               8082,
+				ProjectId:          "projects/rode",
+				NoteId:             "build_collector-build",
+				NamedNotes:         map[string]string{},
+				Debug:              false,
+				BuildStartPolicy:   "default",
+				BuildEndPolicy:     "default",
+				MaxClockSkew:       5 * time.Minute,
+				SlsaVersion:        "v1",
+				SlsaBuildType:      DefaultSlsaBuildType,
+				MaxSbomSize:        DefaultMaxSbomSize,
+				RodeMaxMessageSize: DefaultRodeMaxMessageSize,
 				ClientConfig: &common.ClientConfig{
 					Rode: &common.RodeClientConfig{
 						Host: "rode:50051",
@@ -53,8 +84,18 @@ var _ = Describe("Config", func() {
 				},
 			}),
 			Entry("Rode host flag", []string{"--rode-host=bar"}, &Config{
-				Port:  8082,
-				Debug: false,
+				Port:               8082,
+				ProjectId:          "projects/rode",
+				NoteId:             "build_collector-build",
+				NamedNotes:         map[string]string{},
+				Debug:              false,
+				BuildStartPolicy:   "default",
+				BuildEndPolicy:     "default",
+				MaxClockSkew:       5 * time.Minute,
+				SlsaVersion:        "v1",
+				SlsaBuildType:      DefaultSlsaBuildType,
+				MaxSbomSize:        DefaultMaxSbomSize,
+				RodeMaxMessageSize: DefaultRodeMaxMessageSize,
 				ClientConfig: &common.ClientConfig{
 					Rode: &common.RodeClientConfig{
 						Host: "bar",
@@ -63,9 +104,145 @@ var _ = Describe("Config", func() {
 					BasicAuth: &common.BasicAuthConfig{},
 				},
 			}),
+			Entry("strict artifact matching flag", []string{"--strict-artifact-matching"}, &Config{
+				Port:                   8082,
+				ProjectId:              "projects/rode",
+				NoteId:                 "build_collector-build",
+				NamedNotes:             map[string]string{},
+				StrictArtifactMatching: true,
+				BuildStartPolicy:       "default",
+				BuildEndPolicy:         "default",
+				MaxClockSkew:           5 * time.Minute,
+				SlsaVersion:            "v1",
+				SlsaBuildType:          DefaultSlsaBuildType,
+				MaxSbomSize:            DefaultMaxSbomSize,
+				RodeMaxMessageSize:     DefaultRodeMaxMessageSize,
+				ClientConfig: &common.ClientConfig{
+					Rode: &common.RodeClientConfig{
+						Host: "rode:50051",
+					},
+					OIDCAuth:  &common.OIDCAuthConfig{},
+					BasicAuth: &common.BasicAuthConfig{},
+				},
+			}),
+			Entry("note flags", []string{"--project-id=projects/acme", "--note-id=ci", "--named-notes=release=release-builds, nightly=nightly-builds"}, &Config{
+				Port:               8082,
+				ProjectId:          "projects/acme",
+				NoteId:             "ci",
+				NamedNotes:         map[string]string{"release": "release-builds", "nightly": "nightly-builds"},
+				BuildStartPolicy:   "default",
+				BuildEndPolicy:     "default",
+				MaxClockSkew:       5 * time.Minute,
+				SlsaVersion:        "v1",
+				SlsaBuildType:      DefaultSlsaBuildType,
+				MaxSbomSize:        DefaultMaxSbomSize,
+				RodeMaxMessageSize: DefaultRodeMaxMessageSize,
+				ClientConfig: &common.ClientConfig{
+					Rode: &common.RodeClientConfig{
+						Host: "rode:50051",
+					},
+					OIDCAuth:  &common.OIDCAuthConfig{},
+					BasicAuth: &common.BasicAuthConfig{},
+				},
+			}),
+			Entry("redaction flags", []string{"--redact-query-params=session, ticket", "--redact-pattern=glpat-[A-Za-z0-9_-]+", "--redact-pattern=ghp_[A-Za-z0-9]+"}, &Config{
+				Port:               8082,
+				ProjectId:          "projects/rode",
+				NoteId:             "build_collector-build",
+				NamedNotes:         map[string]string{},
+				RedactQueryParams:  []string{"session", "ticket"},
+				BuildStartPolicy:   "default",
+				BuildEndPolicy:     "default",
+				MaxClockSkew:       5 * time.Minute,
+				SlsaVersion:        "v1",
+				SlsaBuildType:      DefaultSlsaBuildType,
+				MaxSbomSize:        DefaultMaxSbomSize,
+				RodeMaxMessageSize: DefaultRodeMaxMessageSize,
+				RedactPatterns: []*regexp.Regexp{
+					regexp.MustCompile("glpat-[A-Za-z0-9_-]+"),
+					regexp.MustCompile("ghp_[A-Za-z0-9]+"),
+				},
+				ClientConfig: &common.ClientConfig{
+					Rode: &common.RodeClientConfig{
+						Host: "rode:50051",
+					},
+					OIDCAuth:  &common.OIDCAuthConfig{},
+					BasicAuth: &common.BasicAuthConfig{},
+				},
+			}),
+			Entry("timestamp flags", []string{"--build-start-policy=reject", "--build-end-policy=clamp", "--max-clock-skew=30s", "--max-build-duration=2h"}, &Config{
+				Port:               8082,
+				ProjectId:          "projects/rode",
+				NoteId:             "build_collector-build",
+				NamedNotes:         map[string]string{},
+				BuildStartPolicy:   "reject",
+				BuildEndPolicy:     "clamp",
+				MaxClockSkew:       30 * time.Second,
+				MaxBuildDuration:   2 * time.Hour,
+				SlsaVersion:        "v1",
+				SlsaBuildType:      DefaultSlsaBuildType,
+				MaxSbomSize:        DefaultMaxSbomSize,
+				RodeMaxMessageSize: DefaultRodeMaxMessageSize,
+				ClientConfig: &common.ClientConfig{
+					Rode: &common.RodeClientConfig{
+						Host: "rode:50051",
+					},
+					OIDCAuth:  &common.OIDCAuthConfig{},
+					BasicAuth: &common.BasicAuthConfig{},
+				},
+			}),
+			Entry("slsa flags", []string{"--slsa-version=v0.2", "--slsa-build-type=https://example.com/build@v1"}, &Config{
+				Port:               8082,
+				ProjectId:          "projects/rode",
+				NoteId:             "build_collector-build",
+				NamedNotes:         map[string]string{},
+				BuildStartPolicy:   "default",
+				BuildEndPolicy:     "default",
+				MaxClockSkew:       5 * time.Minute,
+				SlsaVersion:        "v0.2",
+				SlsaBuildType:      "https://example.com/build@v1",
+				MaxSbomSize:        DefaultMaxSbomSize,
+				RodeMaxMessageSize: DefaultRodeMaxMessageSize,
+				ClientConfig: &common.ClientConfig{
+					Rode: &common.RodeClientConfig{
+						Host: "rode:50051",
+					},
+					OIDCAuth:  &common.OIDCAuthConfig{},
+					BasicAuth: &common.BasicAuthConfig{},
+				},
+			}),
+			Entry("slsa disabled", []string{"--slsa-version=none", "--slsa-build-type="}, &Config{
+				Port:               8082,
+				ProjectId:          "projects/rode",
+				NoteId:             "build_collector-build",
+				NamedNotes:         map[string]string{},
+				BuildStartPolicy:   "default",
+				BuildEndPolicy:     "default",
+				MaxClockSkew:       5 * time.Minute,
+				SlsaVersion:        "none",
+				MaxSbomSize:        DefaultMaxSbomSize,
+				RodeMaxMessageSize: DefaultRodeMaxMessageSize,
+				ClientConfig: &common.ClientConfig{
+					Rode: &common.RodeClientConfig{
+						Host: "rode:50051",
+					},
+					OIDCAuth:  &common.OIDCAuthConfig{},
+					BasicAuth: &common.BasicAuthConfig{},
+				},
+			}),
 			Entry("Rode insecure flag", []string{"--rode-insecure-disable-transport-security"}, &Config{
-				Port:  8082,
-				Debug: false,
+				Port:               8082,
+				ProjectId:          "projects/rode",
+				NoteId:             "build_collector-build",
+				NamedNotes:         map[string]string{},
+				Debug:              false,
+				BuildStartPolicy:   "default",
+				BuildEndPolicy:     "default",
+				MaxClockSkew:       5 * time.Minute,
+				SlsaVersion:        "v1",
+				SlsaBuildType:      DefaultSlsaBuildType,
+				MaxSbomSize:        DefaultMaxSbomSize,
+				RodeMaxMessageSize: DefaultRodeMaxMessageSize,
 				ClientConfig: &common.ClientConfig{
 					Rode: &common.RodeClientConfig{
 						Host:                     "rode:50051",
@@ -75,6 +252,90 @@ var _ = Describe("Config", func() {
 					BasicAuth: &common.BasicAuthConfig{},
 				},
 			}),
+			Entry("raised Rode message limit", []string{"--max-sbom-size=16777216", "--rode-max-message-size=33554432"}, &Config{
+				Port:               8082,
+				ProjectId:          "projects/rode",
+				NoteId:             "build_collector-build",
+				NamedNotes:         map[string]string{},
+				BuildStartPolicy:   "default",
+				BuildEndPolicy:     "default",
+				MaxClockSkew:       5 * time.Minute,
+				SlsaVersion:        "v1",
+				SlsaBuildType:      DefaultSlsaBuildType,
+				MaxSbomSize:        16 << 20,
+				RodeMaxMessageSize: 32 << 20,
+				ClientConfig: &common.ClientConfig{
+					Rode: &common.RodeClientConfig{
+						Host: "rode:50051",
+					},
+					OIDCAuth:  &common.OIDCAuthConfig{},
+					BasicAuth: &common.BasicAuthConfig{},
+				},
+			}),
 		)
 	})
+
+	Describe("NoteName", func() {
+		var c *Config
+
+		BeforeEach(func() {
+			c = &Config{
+				ProjectId:  "projects/rode",
+				NoteId:     "build_collector-build",
+				NamedNotes: map[string]string{"release": "release-builds"},
+			}
+		})
+
+		It("should resolve the default note when no name is given", func() {
+			Expect(c.NoteName("")).To(Equal("projects/rode/notes/build_collector-build"))
+		})
+
+		It("should resolve a named note", func() {
+			Expect(c.NoteName("release")).To(Equal("projects/rode/notes/release-builds"))
+		})
+
+		It("should return an error for an unknown note", func() {
+			_, err := c.NoteName("nightly")
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should list every configured note", func() {
+			Expect(c.NoteNames()).To(Equal([]string{
+				"projects/rode/notes/build_collector-build",
+				"projects/rode/notes/release-builds",
+			}))
+		})
+
+		It("should read occurrences of the legacy note", func() {
+			Expect(c.ReadNoteNames()).To(Equal([]string{
+				"projects/rode/notes/build_collector-build",
+				"projects/rode/notes/release-builds",
+				LegacyNoteName,
+			}))
+		})
+
+		It("should not list the legacy note twice when it's configured", func() {
+			c.NoteId = "build_collector"
+
+			Expect(c.ReadNoteNames()).To(Equal([]string{
+				LegacyNoteName,
+				"projects/rode/notes/release-builds",
+			}))
+		})
+	})
+
+	Describe("MaxRequestSize", func() {
+		It("should accept gRPC's default message size for small SBOM limits", func() {
+			c := &Config{MaxSbomSize: 1024}
+
+			Expect(c.MaxRequestSize()).To(Equal(DefaultRodeMaxMessageSize))
+		})
+
+		It("should accept an SBOM up to the limit in a single request", func() {
+			c := &Config{MaxSbomSize: 16 << 20}
+
+			Expect(c.MaxRequestSize()).To(Equal(16<<20 + SbomMessageOverhead))
+		})
+	})
 })
@@ -0,0 +1,85 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SigningKey is the private key the collector signs the build occurrences it writes with
+type SigningKey struct {
+	Id         string
+	PrivateKey crypto.Signer
+}
+
+// loadSigningKey reads a PEM encoded Ed25519 or ECDSA P-256 private key. When id is empty, the name of the key's file
+// without its extension is used.
+func loadSigningKey(path, id string) (*SigningKey, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading signing key: %w", err)
+	}
+
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		return nil, fmt.Errorf("signing key %s is not PEM encoded", path)
+	}
+
+	var privateKey interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		privateKey, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("signing key %s has unsupported PEM type %q", path, block.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error parsing signing key %s: %w", path, err)
+	}
+
+	var signer crypto.Signer
+	switch k := privateKey.(type) {
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("signing key %s must use the P-256 curve", path)
+		}
+		signer = k
+	case ed25519.PrivateKey:
+		signer = k
+	default:
+		return nil, fmt.Errorf("signing key %s has unsupported type %T, expected Ed25519 or ECDSA", path, privateKey)
+	}
+
+	if id == "" {
+		name := filepath.Base(path)
+		id = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+
+	return &SigningKey{
+		Id:         id,
+		PrivateKey: signer,
+	}, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("signing key", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "signing-key")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	writeKey := func(name, pemType string, der []byte) string {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: pemType, Bytes: der}), 0600)).To(Succeed())
+
+		return path
+	}
+
+	It("should load an Ed25519 key named after its file", func() {
+		_, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+		der, _ := x509.MarshalPKCS8PrivateKey(privateKey)
+		path := writeKey("collector.pem", "PRIVATE KEY", der)
+
+		c, err := Build("collector-build", []string{"--signing-key-file=" + path})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.SigningKey).To(Equal(&SigningKey{Id: "collector", PrivateKey: privateKey}))
+	})
+
+	It("should load a SEC 1 ECDSA key with the configured id", func() {
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		der, _ := x509.MarshalECPrivateKey(privateKey)
+		path := writeKey("collector.pem", "EC PRIVATE KEY", der)
+
+		c, err := Build("collector-build", []string{"--signing-key-file=" + path, "--signing-key-id=collector-2021"})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.SigningKey.Id).To(Equal("collector-2021"))
+		Expect(c.SigningKey.PrivateKey.Public()).To(Equal(&privateKey.PublicKey))
+	})
+
+	It("should reject ECDSA keys that don't use P-256", func() {
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		der, _ := x509.MarshalPKCS8PrivateKey(privateKey)
+		path := writeKey("collector.pem", "PRIVATE KEY", der)
+
+		_, err := loadSigningKey(path, "")
+
+		Expect(err).To(MatchError(ContainSubstring("P-256")))
+	})
+
+	It("should reject RSA keys", func() {
+		privateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+		der, _ := x509.MarshalPKCS8PrivateKey(privateKey)
+		path := writeKey("collector.pem", "PRIVATE KEY", der)
+
+		_, err := loadSigningKey(path, "")
+
+		Expect(err).To(MatchError(ContainSubstring("unsupported type")))
+	})
+
+	It("should reject a missing key file", func() {
+		_, err := Build("collector-build", []string{"--signing-key-file=" + filepath.Join(dir, "missing.pem")})
+
+		Expect(err).To(HaveOccurred())
+	})
+})
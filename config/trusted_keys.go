@@ -0,0 +1,132 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// trustedKeyExtensions are the file extensions loaded from the trusted keys directory
+var trustedKeyExtensions = []string{".pem", ".pub"}
+
+// TrustedKey is a public key that signed provenance is verified against. The id is the name of the key's file
+// without its extension, and is matched against the keyid of DSSE signatures.
+type TrustedKey struct {
+	Id        string
+	PublicKey crypto.PublicKey
+}
+
+// loadTrustedKeys reads PEM encoded ECDSA P-256, Ed25519 and RSA public keys from the given files and from every
+// .pem or .pub file in dir
+func loadTrustedKeys(files []string, dir string) ([]*TrustedKey, error) {
+	paths := append([]string(nil), files...)
+	if dir != "" {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("error reading trusted keys directory: %w", err)
+		}
+
+		var dirPaths []string
+		for _, entry := range entries {
+			if !entry.IsDir() && hasTrustedKeyExtension(entry.Name()) {
+				dirPaths = append(dirPaths, filepath.Join(dir, entry.Name()))
+			}
+		}
+		sort.Strings(dirPaths)
+		paths = append(paths, dirPaths...)
+	}
+
+	var keys []*TrustedKey
+	ids := map[string]bool{}
+	for _, path := range paths {
+		key, err := loadTrustedKey(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if ids[key.Id] {
+			return nil, fmt.Errorf("trusted key id %q is used by more than one key", key.Id)
+		}
+		ids[key.Id] = true
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func loadTrustedKey(path string) (*TrustedKey, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading trusted key: %w", err)
+	}
+
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		return nil, fmt.Errorf("trusted key %s is not PEM encoded", path)
+	}
+
+	var publicKey crypto.PublicKey
+	switch block.Type {
+	case "PUBLIC KEY":
+		publicKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		publicKey, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("trusted key %s has unsupported PEM type %q", path, block.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error parsing trusted key %s: %w", path, err)
+	}
+
+	switch k := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("trusted key %s must use the P-256 curve", path)
+		}
+	case ed25519.PublicKey, *rsa.PublicKey:
+	default:
+		return nil, fmt.Errorf("trusted key %s has unsupported type %T", path, publicKey)
+	}
+
+	name := filepath.Base(path)
+
+	return &TrustedKey{
+		Id:        strings.TrimSuffix(name, filepath.Ext(name)),
+		PublicKey: publicKey,
+	}, nil
+}
+
+func hasTrustedKeyExtension(name string) bool {
+	for _, extension := range trustedKeyExtensions {
+		if strings.EqualFold(filepath.Ext(name), extension) {
+			return true
+		}
+	}
+
+	return false
+}
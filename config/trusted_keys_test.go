@@ -0,0 +1,123 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("trusted keys", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "trusted-keys")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	writeKey := func(name string, publicKey crypto.PublicKey) string {
+		der, err := x509.MarshalPKIXPublicKey(publicKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600)).To(Succeed())
+
+		return path
+	}
+
+	It("should load ECDSA, Ed25519 and RSA keys from a directory", func() {
+		ecdsaKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		ed25519Key, _, _ := ed25519.GenerateKey(rand.Reader)
+		rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+		writeKey("ci.pem", &ecdsaKey.PublicKey)
+		writeKey("release.pub", ed25519Key)
+		writeKey("legacy.pem", &rsaKey.PublicKey)
+		Expect(os.WriteFile(filepath.Join(dir, "README.md"), []byte("keys"), 0600)).To(Succeed())
+
+		c, err := Build("collector-build", []string{"--trusted-keys-dir=" + dir, "--require-signed-provenance"})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.RequireSignedProvenance).To(BeTrue())
+		Expect(c.TrustedKeys).To(Equal([]*TrustedKey{
+			{Id: "ci", PublicKey: &ecdsaKey.PublicKey},
+			{Id: "legacy", PublicKey: &rsaKey.PublicKey},
+			{Id: "release", PublicKey: ed25519Key},
+		}))
+	})
+
+	It("should load individual key files", func() {
+		ed25519Key, _, _ := ed25519.GenerateKey(rand.Reader)
+		path := writeKey("release.pem", ed25519Key)
+
+		c, err := Build("collector-build", []string{"--trusted-key-file=" + path})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.TrustedKeys).To(Equal([]*TrustedKey{{Id: "release", PublicKey: ed25519Key}}))
+	})
+
+	It("should load PKCS #1 RSA keys", func() {
+		rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+		path := filepath.Join(dir, "legacy.pem")
+		Expect(os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)}), 0600)).To(Succeed())
+
+		keys, err := loadTrustedKeys([]string{path}, "")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys[0].PublicKey).To(Equal(&rsaKey.PublicKey))
+	})
+
+	It("should reject ECDSA keys that don't use P-256", func() {
+		ecdsaKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		path := writeKey("ci.pem", &ecdsaKey.PublicKey)
+
+		_, err := loadTrustedKeys([]string{path}, "")
+
+		Expect(err).To(MatchError(ContainSubstring("P-256")))
+	})
+
+	It("should reject files that aren't PEM encoded", func() {
+		path := filepath.Join(dir, "ci.pem")
+		Expect(os.WriteFile(path, []byte("not a key"), 0600)).To(Succeed())
+
+		_, err := loadTrustedKeys([]string{path}, "")
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject keys with the same id", func() {
+		ed25519Key, _, _ := ed25519.GenerateKey(rand.Reader)
+		path := writeKey("release.pem", ed25519Key)
+
+		_, err := loadTrustedKeys([]string{path}, dir)
+
+		Expect(err).To(MatchError(ContainSubstring("more than one key")))
+	})
+})
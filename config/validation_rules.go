@@ -0,0 +1,107 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// ValidationRuleFields are the request fields that validation rules can be written for
+var ValidationRuleFields = []string{
+	"repository",
+	"repository_host",
+	"commit_id",
+	"commit_uri",
+	"logs_uri",
+	"provenance_id",
+	"creator",
+	"note",
+	"artifact_id",
+	"artifact_name",
+	"material_uri",
+	"builder_id",
+}
+
+// ValidationRule is an organization-specific constraint on a request field. A rule can require the field to be set,
+// and restrict its values to those matching a pattern or in an allowlist.
+type ValidationRule struct {
+	Field     string
+	Required  bool
+	Pattern   *regexp.Regexp
+	Allowlist []string
+}
+
+type validationRulesFile struct {
+	Rules []struct {
+		Field     string   `json:"field"`
+		Required  bool     `json:"required"`
+		Pattern   string   `json:"pattern"`
+		Allowlist []string `json:"allowlist"`
+	} `json:"rules"`
+}
+
+// loadValidationRules reads validation rules from a JSON file in the form
+// {"rules": [{"field": "creator", "required": true, "pattern": "@example\\.com$"}]}
+func loadValidationRules(path string) ([]*ValidationRule, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading validation rules: %s", err)
+	}
+
+	var file validationRulesFile
+	if err := json.Unmarshal(contents, &file); err != nil {
+		return nil, fmt.Errorf("error parsing validation rules: %s", err)
+	}
+
+	var rules []*ValidationRule
+	for i, r := range file.Rules {
+		if !isValidationRuleField(r.Field) {
+			return nil, fmt.Errorf("validation rule %d has unknown field %q", i, r.Field)
+		}
+
+		rule := &ValidationRule{
+			Field:     r.Field,
+			Required:  r.Required,
+			Allowlist: r.Allowlist,
+		}
+
+		if r.Pattern != "" {
+			if rule.Pattern, err = regexp.Compile(r.Pattern); err != nil {
+				return nil, fmt.Errorf("validation rule %d has an invalid pattern: %s", i, err)
+			}
+		}
+
+		if !rule.Required && rule.Pattern == nil && len(rule.Allowlist) == 0 {
+			return nil, fmt.Errorf("validation rule %d for %s has no constraints", i, r.Field)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func isValidationRuleField(field string) bool {
+	for _, f := range ValidationRuleFields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("validation rules", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "validation-rules")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	writeRules := func(contents string) string {
+		path := filepath.Join(dir, "rules.json")
+		Expect(os.WriteFile(path, []byte(contents), 0600)).To(Succeed())
+
+		return path
+	}
+
+	It("should load the rules from the file", func() {
+		path := writeRules(`{
+			"rules": [
+				{"field": "creator", "required": true, "pattern": "@example\\.com$"},
+				{"field": "repository_host", "allowlist": ["github.com", "gitlab.example.com"]}
+			]
+		}`)
+
+		c, err := Build("collector-build", []string{"--validation-rules-file=" + path})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.ValidationRules).To(Equal([]*ValidationRule{
+			{
+				Field:    "creator",
+				Required: true,
+				Pattern:  regexp.MustCompile(`@example\.com$`),
+			},
+			{
+				Field:     "repository_host",
+				Allowlist: []string{"github.com", "gitlab.example.com"},
+			},
+		}))
+	})
+
+	It("should return an error when the file doesn't exist", func() {
+		_, err := Build("collector-build", []string{"--validation-rules-file=" + filepath.Join(dir, "missing.json")})
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable("invalid rules", func(contents string) {
+		_, err := Build("collector-build", []string{"--validation-rules-file=" + writeRules(contents)})
+
+		Expect(err).To(HaveOccurred())
+	},
+		Entry("malformed json", `{"rules": [`),
+		Entry("unknown field", `{"rules": [{"field": "color", "required": true}]}`),
+		Entry("invalid pattern", `{"rules": [{"field": "creator", "pattern": "("}]}`),
+		Entry("no constraints", `{"rules": [{"field": "creator"}]}`),
+	)
+})
@@ -23,6 +23,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/rode/collector-build/proto/v1alpha1"
@@ -31,6 +32,8 @@ import (
 	"github.com/soheilhy/cmux"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	// the gateway resolves error details from the global registry when writing them as JSON
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
@@ -38,6 +41,8 @@ import (
 	"github.com/rode/collector-build/config"
 )
 
+const registrationRetryInterval = 5 * time.Second
+
 func main() {
 	conf, err := config.Build(os.Args[0], os.Args[1:])
 	if err != nil {
@@ -59,13 +64,13 @@ func main() {
 		logger.Fatal("could not create rode client", zap.Error(err))
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpc.MaxRecvMsgSize(conf.MaxRequestSize()))
 
 	if conf.Debug {
 		reflection.Register(grpcServer)
 	}
 
-	buildCollectorServer := server.NewBuildCollectorServer(logger, rodeClient)
+	buildCollectorServer := server.NewBuildCollectorServer(logger, rodeClient, conf)
 	v1alpha1.RegisterBuildCollectorServer(grpcServer, buildCollectorServer)
 
 	healthzServer := server.NewHealthzServer(logger.Named("healthz"))
@@ -99,7 +104,17 @@ func main() {
 	})
 
 	logger.Info("listening", zap.String("host", lis.Addr().String()))
-	healthzServer.Ready()
+
+	// shutdownCtx is cancelled once a termination signal is received, which stops registration from being retried
+	shutdownCtx, shutdown := context.WithCancel(context.Background())
+	registrationDone := make(chan struct{})
+	go func() {
+		defer close(registrationDone)
+
+		if registerCollector(shutdownCtx, logger, buildCollectorServer) && shutdownCtx.Err() == nil {
+			healthzServer.Ready()
+		}
+	}()
 
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
@@ -107,12 +122,34 @@ func main() {
 	terminationSignal := <-sig
 
 	logger.Info("shutting down...", zap.String("termination signal", terminationSignal.String()))
+	shutdown()
+	// wait for registration to stop so that it can't mark the collector ready after it's been marked not ready
+	<-registrationDone
 	healthzServer.NotReady()
 
 	grpcServer.GracefulStop()
 	httpServer.Shutdown(context.Background())
 }
 
+// registerCollector retries registration with Rode until it succeeds, returning false if ctx is cancelled first
+func registerCollector(ctx context.Context, logger *zap.Logger, buildCollectorServer *server.BuildCollectorServer) bool {
+	for ctx.Err() == nil {
+		err := buildCollectorServer.RegisterCollector(ctx)
+		if err == nil {
+			return ctx.Err() == nil
+		}
+
+		logger.Warn("failed to register collector with Rode, retrying", zap.Error(err), zap.Duration("interval", registrationRetryInterval))
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(registrationRetryInterval):
+		}
+	}
+
+	return false
+}
+
 func createGrpcGateway(ctx context.Context, grpcAddress string) (http.Handler, error) {
 	conn, err := grpc.DialContext(
 		context.Background(),
@@ -122,7 +159,7 @@ func createGrpcGateway(ctx context.Context, grpcAddress string) (http.Handler, e
 	if err != nil {
 		log.Fatalln("Failed to dial server:", err)
 	}
-	gwmux := runtime.NewServeMux()
+	gwmux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, server.NewGatewayMarshaler()))
 	if err := v1alpha1.RegisterBuildCollectorHandler(ctx, gwmux, conn); err != nil {
 		return nil, err
 	}
@@ -22,8 +22,10 @@ package v1alpha1
 
 import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	status "google.golang.org/genproto/googleapis/rpc/status"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -36,17 +38,288 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type Artifact struct {
+type CloudRepoSource_AliasKind int32
+
+const (
+	CloudRepoSource_ALIAS_KIND_UNSPECIFIED CloudRepoSource_AliasKind = 0
+	// an alias that always refers to the same revision, such as a tag
+	CloudRepoSource_FIXED CloudRepoSource_AliasKind = 1
+	// an alias that can move between revisions, such as a branch
+	CloudRepoSource_MOVABLE CloudRepoSource_AliasKind = 2
+	CloudRepoSource_OTHER   CloudRepoSource_AliasKind = 3
+)
+
+// Enum value maps for CloudRepoSource_AliasKind.
+var (
+	CloudRepoSource_AliasKind_name = map[int32]string{
+		0: "ALIAS_KIND_UNSPECIFIED",
+		1: "FIXED",
+		2: "MOVABLE",
+		3: "OTHER",
+	}
+	CloudRepoSource_AliasKind_value = map[string]int32{
+		"ALIAS_KIND_UNSPECIFIED": 0,
+		"FIXED":                  1,
+		"MOVABLE":                2,
+		"OTHER":                  3,
+	}
+)
+
+func (x CloudRepoSource_AliasKind) Enum() *CloudRepoSource_AliasKind {
+	p := new(CloudRepoSource_AliasKind)
+	*p = x
+	return p
+}
+
+func (x CloudRepoSource_AliasKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CloudRepoSource_AliasKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1alpha1_build_collector_proto_enumTypes[0].Descriptor()
+}
+
+func (CloudRepoSource_AliasKind) Type() protoreflect.EnumType {
+	return &file_proto_v1alpha1_build_collector_proto_enumTypes[0]
+}
+
+func (x CloudRepoSource_AliasKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CloudRepoSource_AliasKind.Descriptor instead.
+func (CloudRepoSource_AliasKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{7, 0}
+}
+
+type CreateBuildRequest_IdempotencyMode int32
+
+const (
+	// return the id of the existing build occurrence
+	CreateBuildRequest_RETURN_EXISTING CreateBuildRequest_IdempotencyMode = 0
+	// fail the request with ALREADY_EXISTS
+	CreateBuildRequest_FAIL_IF_EXISTS CreateBuildRequest_IdempotencyMode = 1
+	// create a new build occurrence regardless of any existing one
+	CreateBuildRequest_ALWAYS_CREATE CreateBuildRequest_IdempotencyMode = 2
+)
+
+// Enum value maps for CreateBuildRequest_IdempotencyMode.
+var (
+	CreateBuildRequest_IdempotencyMode_name = map[int32]string{
+		0: "RETURN_EXISTING",
+		1: "FAIL_IF_EXISTS",
+		2: "ALWAYS_CREATE",
+	}
+	CreateBuildRequest_IdempotencyMode_value = map[string]int32{
+		"RETURN_EXISTING": 0,
+		"FAIL_IF_EXISTS":  1,
+		"ALWAYS_CREATE":   2,
+	}
+)
+
+func (x CreateBuildRequest_IdempotencyMode) Enum() *CreateBuildRequest_IdempotencyMode {
+	p := new(CreateBuildRequest_IdempotencyMode)
+	*p = x
+	return p
+}
+
+func (x CreateBuildRequest_IdempotencyMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CreateBuildRequest_IdempotencyMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1alpha1_build_collector_proto_enumTypes[1].Descriptor()
+}
+
+func (CreateBuildRequest_IdempotencyMode) Type() protoreflect.EnumType {
+	return &file_proto_v1alpha1_build_collector_proto_enumTypes[1]
+}
+
+func (x CreateBuildRequest_IdempotencyMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CreateBuildRequest_IdempotencyMode.Descriptor instead.
+func (CreateBuildRequest_IdempotencyMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{9, 0}
+}
+
+type UpdateBuildArtifactsResponse_ArtifactStatus int32
+
+const (
+	UpdateBuildArtifactsResponse_ARTIFACT_STATUS_UNSPECIFIED UpdateBuildArtifactsResponse_ArtifactStatus = 0
+	// the artifact was appended to the build
+	UpdateBuildArtifactsResponse_ADDED UpdateBuildArtifactsResponse_ArtifactStatus = 1
+	// the artifact id was already on the build, and new names were merged into it
+	UpdateBuildArtifactsResponse_MERGED UpdateBuildArtifactsResponse_ArtifactStatus = 2
+	// the artifact and all of its names were already on the build, nothing was changed
+	UpdateBuildArtifactsResponse_ALREADY_PRESENT UpdateBuildArtifactsResponse_ArtifactStatus = 3
+)
+
+// Enum value maps for UpdateBuildArtifactsResponse_ArtifactStatus.
+var (
+	UpdateBuildArtifactsResponse_ArtifactStatus_name = map[int32]string{
+		0: "ARTIFACT_STATUS_UNSPECIFIED",
+		1: "ADDED",
+		2: "MERGED",
+		3: "ALREADY_PRESENT",
+	}
+	UpdateBuildArtifactsResponse_ArtifactStatus_value = map[string]int32{
+		"ARTIFACT_STATUS_UNSPECIFIED": 0,
+		"ADDED":                       1,
+		"MERGED":                      2,
+		"ALREADY_PRESENT":             3,
+	}
+)
+
+func (x UpdateBuildArtifactsResponse_ArtifactStatus) Enum() *UpdateBuildArtifactsResponse_ArtifactStatus {
+	p := new(UpdateBuildArtifactsResponse_ArtifactStatus)
+	*p = x
+	return p
+}
+
+func (x UpdateBuildArtifactsResponse_ArtifactStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UpdateBuildArtifactsResponse_ArtifactStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1alpha1_build_collector_proto_enumTypes[2].Descriptor()
+}
+
+func (UpdateBuildArtifactsResponse_ArtifactStatus) Type() protoreflect.EnumType {
+	return &file_proto_v1alpha1_build_collector_proto_enumTypes[2]
+}
+
+func (x UpdateBuildArtifactsResponse_ArtifactStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UpdateBuildArtifactsResponse_ArtifactStatus.Descriptor instead.
+func (UpdateBuildArtifactsResponse_ArtifactStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{15, 0}
+}
+
+type Build_TimestampAdjustment int32
+
+const (
+	// the timestamp was recorded as submitted
+	Build_TIMESTAMP_ADJUSTMENT_UNSPECIFIED Build_TimestampAdjustment = 0
+	// the timestamp was missing or invalid and was replaced with the time the build was recorded
+	Build_DEFAULTED Build_TimestampAdjustment = 1
+	// the timestamp was moved to the nearest time that passed validation
+	Build_CLAMPED Build_TimestampAdjustment = 2
+)
+
+// Enum value maps for Build_TimestampAdjustment.
+var (
+	Build_TimestampAdjustment_name = map[int32]string{
+		0: "TIMESTAMP_ADJUSTMENT_UNSPECIFIED",
+		1: "DEFAULTED",
+		2: "CLAMPED",
+	}
+	Build_TimestampAdjustment_value = map[string]int32{
+		"TIMESTAMP_ADJUSTMENT_UNSPECIFIED": 0,
+		"DEFAULTED":                        1,
+		"CLAMPED":                          2,
+	}
+)
+
+func (x Build_TimestampAdjustment) Enum() *Build_TimestampAdjustment {
+	p := new(Build_TimestampAdjustment)
+	*p = x
+	return p
+}
+
+func (x Build_TimestampAdjustment) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Build_TimestampAdjustment) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1alpha1_build_collector_proto_enumTypes[3].Descriptor()
+}
+
+func (Build_TimestampAdjustment) Type() protoreflect.EnumType {
+	return &file_proto_v1alpha1_build_collector_proto_enumTypes[3]
+}
+
+func (x Build_TimestampAdjustment) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Build_TimestampAdjustment.Descriptor instead.
+func (Build_TimestampAdjustment) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{21, 0}
+}
+
+type AttachSbomRequest_Format int32
+
+const (
+	AttachSbomRequest_FORMAT_UNSPECIFIED AttachSbomRequest_Format = 0
+	AttachSbomRequest_CYCLONEDX_JSON     AttachSbomRequest_Format = 1
+	AttachSbomRequest_CYCLONEDX_XML      AttachSbomRequest_Format = 2
+	AttachSbomRequest_SPDX_JSON          AttachSbomRequest_Format = 3
+	AttachSbomRequest_SPDX_TAG_VALUE     AttachSbomRequest_Format = 4
+)
+
+// Enum value maps for AttachSbomRequest_Format.
+var (
+	AttachSbomRequest_Format_name = map[int32]string{
+		0: "FORMAT_UNSPECIFIED",
+		1: "CYCLONEDX_JSON",
+		2: "CYCLONEDX_XML",
+		3: "SPDX_JSON",
+		4: "SPDX_TAG_VALUE",
+	}
+	AttachSbomRequest_Format_value = map[string]int32{
+		"FORMAT_UNSPECIFIED": 0,
+		"CYCLONEDX_JSON":     1,
+		"CYCLONEDX_XML":      2,
+		"SPDX_JSON":          3,
+		"SPDX_TAG_VALUE":     4,
+	}
+)
+
+func (x AttachSbomRequest_Format) Enum() *AttachSbomRequest_Format {
+	p := new(AttachSbomRequest_Format)
+	*p = x
+	return p
+}
+
+func (x AttachSbomRequest_Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AttachSbomRequest_Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1alpha1_build_collector_proto_enumTypes[4].Descriptor()
+}
+
+func (AttachSbomRequest_Format) Type() protoreflect.EnumType {
+	return &file_proto_v1alpha1_build_collector_proto_enumTypes[4]
+}
+
+func (x AttachSbomRequest_Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AttachSbomRequest_Format.Descriptor instead.
+func (AttachSbomRequest_Format) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{31, 0}
+}
+
+// A content digest of an artifact
+type Digest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id    string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Names []string `protobuf:"bytes,2,rep,name=names,proto3" json:"names,omitempty"`
+	// sha256, sha512 or sha1
+	Algorithm string `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// hex encoded value of the digest
+	Hex string `protobuf:"bytes,2,opt,name=hex,proto3" json:"hex,omitempty"`
 }
 
-func (x *Artifact) Reset() {
-	*x = Artifact{}
+func (x *Digest) Reset() {
+	*x = Digest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -54,13 +327,13 @@ func (x *Artifact) Reset() {
 	}
 }
 
-func (x *Artifact) String() string {
+func (x *Digest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Artifact) ProtoMessage() {}
+func (*Digest) ProtoMessage() {}
 
-func (x *Artifact) ProtoReflect() protoreflect.Message {
+func (x *Digest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -72,52 +345,41 @@ func (x *Artifact) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Artifact.ProtoReflect.Descriptor instead.
-func (*Artifact) Descriptor() ([]byte, []int) {
+// Deprecated: Use Digest.ProtoReflect.Descriptor instead.
+func (*Digest) Descriptor() ([]byte, []int) {
 	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *Artifact) GetId() string {
+func (x *Digest) GetAlgorithm() string {
 	if x != nil {
-		return x.Id
+		return x.Algorithm
 	}
 	return ""
 }
 
-func (x *Artifact) GetNames() []string {
+func (x *Digest) GetHex() string {
 	if x != nil {
-		return x.Names
+		return x.Hex
 	}
-	return nil
+	return ""
 }
 
-type CreateBuildRequest struct {
+type Artifact struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The Git repository holding the source code for the artifact(s)
-	Repository string `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
-	// Any generated outputs of the build
-	Artifacts []*Artifact `protobuf:"bytes,2,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
-	// Commit SHA
-	CommitId string `protobuf:"bytes,3,opt,name=commit_id,json=commitId,proto3" json:"commit_id,omitempty"`
-	// source of the build
-	ProvenanceId string `protobuf:"bytes,4,opt,name=provenance_id,json=provenanceId,proto3" json:"provenance_id,omitempty"`
-	// link to the build logs
-	LogsUri string `protobuf:"bytes,5,opt,name=logs_uri,json=logsUri,proto3" json:"logs_uri,omitempty"`
-	// build creator
-	Creator string `protobuf:"bytes,6,opt,name=creator,proto3" json:"creator,omitempty"`
-	// time the build began
-	BuildStart *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=build_start,json=buildStart,proto3" json:"build_start,omitempty"`
-	// timestamp of when the build ended
-	BuildEnd *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=build_end,json=buildEnd,proto3" json:"build_end,omitempty"`
-	// link to a diff of the changeset
-	CommitUri string `protobuf:"bytes,9,opt,name=commit_uri,json=commitUri,proto3" json:"commit_uri,omitempty"`
+	// ids are normalized before they're stored: digests are lowercased, tags are removed from image references and a
+	// structured digest is appended, e.g. registry/repo@sha256:<hex>. Package URLs (pkg:npm/left-pad@1.3.0) are
+	// converted to Rode resource uris where Rode has a matching resource type, e.g. npm://left-pad:1.3.0
+	Id    string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Names []string `protobuf:"bytes,2,rep,name=names,proto3" json:"names,omitempty"`
+	// digest of the artifact, optional if the id already contains one
+	Digest *Digest `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
 }
 
-func (x *CreateBuildRequest) Reset() {
-	*x = CreateBuildRequest{}
+func (x *Artifact) Reset() {
+	*x = Artifact{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -125,13 +387,13 @@ func (x *CreateBuildRequest) Reset() {
 	}
 }
 
-func (x *CreateBuildRequest) String() string {
+func (x *Artifact) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateBuildRequest) ProtoMessage() {}
+func (*Artifact) ProtoMessage() {}
 
-func (x *CreateBuildRequest) ProtoReflect() protoreflect.Message {
+func (x *Artifact) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -143,85 +405,45 @@ func (x *CreateBuildRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateBuildRequest.ProtoReflect.Descriptor instead.
-func (*CreateBuildRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Artifact.ProtoReflect.Descriptor instead.
+func (*Artifact) Descriptor() ([]byte, []int) {
 	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *CreateBuildRequest) GetRepository() string {
-	if x != nil {
-		return x.Repository
-	}
-	return ""
-}
-
-func (x *CreateBuildRequest) GetArtifacts() []*Artifact {
-	if x != nil {
-		return x.Artifacts
-	}
-	return nil
-}
-
-func (x *CreateBuildRequest) GetCommitId() string {
-	if x != nil {
-		return x.CommitId
-	}
-	return ""
-}
-
-func (x *CreateBuildRequest) GetProvenanceId() string {
-	if x != nil {
-		return x.ProvenanceId
-	}
-	return ""
-}
-
-func (x *CreateBuildRequest) GetLogsUri() string {
-	if x != nil {
-		return x.LogsUri
-	}
-	return ""
-}
-
-func (x *CreateBuildRequest) GetCreator() string {
+func (x *Artifact) GetId() string {
 	if x != nil {
-		return x.Creator
+		return x.Id
 	}
 	return ""
 }
 
-func (x *CreateBuildRequest) GetBuildStart() *timestamppb.Timestamp {
+func (x *Artifact) GetNames() []string {
 	if x != nil {
-		return x.BuildStart
+		return x.Names
 	}
 	return nil
 }
 
-func (x *CreateBuildRequest) GetBuildEnd() *timestamppb.Timestamp {
+func (x *Artifact) GetDigest() *Digest {
 	if x != nil {
-		return x.BuildEnd
+		return x.Digest
 	}
 	return nil
 }
 
-func (x *CreateBuildRequest) GetCommitUri() string {
-	if x != nil {
-		return x.CommitUri
-	}
-	return ""
-}
-
-type CreateBuildResponse struct {
+// An input to the build, such as a base image, a locked dependency or a fetched module
+type Material struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Unique id of the new build occurrence
-	BuildOccurrenceId string `protobuf:"bytes,1,opt,name=build_occurrence_id,json=buildOccurrenceId,proto3" json:"build_occurrence_id,omitempty"`
+	Uri string `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	// digests of the material keyed by lowercase algorithm name, e.g. sha256
+	Digest map[string]string `protobuf:"bytes,2,rep,name=digest,proto3" json:"digest,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *CreateBuildResponse) Reset() {
-	*x = CreateBuildResponse{}
+func (x *Material) Reset() {
+	*x = Material{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -229,13 +451,13 @@ func (x *CreateBuildResponse) Reset() {
 	}
 }
 
-func (x *CreateBuildResponse) String() string {
+func (x *Material) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateBuildResponse) ProtoMessage() {}
+func (*Material) ProtoMessage() {}
 
-func (x *CreateBuildResponse) ProtoReflect() protoreflect.Message {
+func (x *Material) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -247,29 +469,47 @@ func (x *CreateBuildResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateBuildResponse.ProtoReflect.Descriptor instead.
-func (*CreateBuildResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Material.ProtoReflect.Descriptor instead.
+func (*Material) Descriptor() ([]byte, []int) {
 	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *CreateBuildResponse) GetBuildOccurrenceId() string {
+func (x *Material) GetUri() string {
 	if x != nil {
-		return x.BuildOccurrenceId
+		return x.Uri
 	}
 	return ""
 }
 
-type UpdateBuildArtifactsRequest struct {
+func (x *Material) GetDigest() map[string]string {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+// A single command run as part of the build
+type BuildStep struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ExistingArtifactId string    `protobuf:"bytes,1,opt,name=existing_artifact_id,json=existingArtifactId,proto3" json:"existing_artifact_id,omitempty"`
-	NewArtifact        *Artifact `protobuf:"bytes,2,opt,name=new_artifact,json=newArtifact,proto3" json:"new_artifact,omitempty"`
+	// optional unique id of the step, used by other steps to declare a dependency on it
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// command or image that was run
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// arguments passed to the command
+	Args []string `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	// environment variables set for the command, in KEY=VALUE form
+	Env []string `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty"`
+	// working directory of the command
+	Dir string `protobuf:"bytes,5,opt,name=dir,proto3" json:"dir,omitempty"`
+	// ids of the steps that had to complete before this step started
+	WaitFor []string `protobuf:"bytes,6,rep,name=wait_for,json=waitFor,proto3" json:"wait_for,omitempty"`
 }
 
-func (x *UpdateBuildArtifactsRequest) Reset() {
-	*x = UpdateBuildArtifactsRequest{}
+func (x *BuildStep) Reset() {
+	*x = BuildStep{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -277,13 +517,13 @@ func (x *UpdateBuildArtifactsRequest) Reset() {
 	}
 }
 
-func (x *UpdateBuildArtifactsRequest) String() string {
+func (x *BuildStep) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateBuildArtifactsRequest) ProtoMessage() {}
+func (*BuildStep) ProtoMessage() {}
 
-func (x *UpdateBuildArtifactsRequest) ProtoReflect() protoreflect.Message {
+func (x *BuildStep) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -295,51 +535,2067 @@ func (x *UpdateBuildArtifactsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateBuildArtifactsRequest.ProtoReflect.Descriptor instead.
-func (*UpdateBuildArtifactsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BuildStep.ProtoReflect.Descriptor instead.
+func (*BuildStep) Descriptor() ([]byte, []int) {
 	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *UpdateBuildArtifactsRequest) GetExistingArtifactId() string {
+func (x *BuildStep) GetId() string {
 	if x != nil {
-		return x.ExistingArtifactId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *UpdateBuildArtifactsRequest) GetNewArtifact() *Artifact {
+func (x *BuildStep) GetName() string {
 	if x != nil {
-		return x.NewArtifact
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-type UpdateBuildArtifactsResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *BuildStep) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
 
-	// Unique id of the updated build occurrence
-	BuildOccurrenceId string `protobuf:"bytes,1,opt,name=build_occurrence_id,json=buildOccurrenceId,proto3" json:"build_occurrence_id,omitempty"`
+func (x *BuildStep) GetEnv() []string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
 }
 
-func (x *UpdateBuildArtifactsResponse) Reset() {
+func (x *BuildStep) GetDir() string {
+	if x != nil {
+		return x.Dir
+	}
+	return ""
+}
+
+func (x *BuildStep) GetWaitFor() []string {
+	if x != nil {
+		return x.WaitFor
+	}
+	return nil
+}
+
+// The system that ran the build
+type Builder struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// identifier of the builder, e.g. https://github.com/actions/runner
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// version of the builder
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *Builder) Reset() {
+	*x = Builder{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Builder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Builder) ProtoMessage() {}
+
+func (x *Builder) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Builder.ProtoReflect.Descriptor instead.
+func (*Builder) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Builder) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Builder) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// Source code hosted in a Git repository, identified by the request repository and commit id
+type GitSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the branch, tag or other ref that was built
+	//
+	// Types that are assignable to RevisionAlias:
+	//	*GitSource_Branch
+	//	*GitSource_Tag
+	//	*GitSource_Ref
+	RevisionAlias isGitSource_RevisionAlias `protobuf_oneof:"revision_alias"`
+}
+
+func (x *GitSource) Reset() {
+	*x = GitSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GitSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GitSource) ProtoMessage() {}
+
+func (x *GitSource) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GitSource.ProtoReflect.Descriptor instead.
+func (*GitSource) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{5}
+}
+
+func (m *GitSource) GetRevisionAlias() isGitSource_RevisionAlias {
+	if m != nil {
+		return m.RevisionAlias
+	}
+	return nil
+}
+
+func (x *GitSource) GetBranch() string {
+	if x, ok := x.GetRevisionAlias().(*GitSource_Branch); ok {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *GitSource) GetTag() string {
+	if x, ok := x.GetRevisionAlias().(*GitSource_Tag); ok {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *GitSource) GetRef() string {
+	if x, ok := x.GetRevisionAlias().(*GitSource_Ref); ok {
+		return x.Ref
+	}
+	return ""
+}
+
+type isGitSource_RevisionAlias interface {
+	isGitSource_RevisionAlias()
+}
+
+type GitSource_Branch struct {
+	Branch string `protobuf:"bytes,1,opt,name=branch,proto3,oneof"`
+}
+
+type GitSource_Tag struct {
+	Tag string `protobuf:"bytes,2,opt,name=tag,proto3,oneof"`
+}
+
+type GitSource_Ref struct {
+	Ref string `protobuf:"bytes,3,opt,name=ref,proto3,oneof"`
+}
+
+func (*GitSource_Branch) isGitSource_RevisionAlias() {}
+
+func (*GitSource_Tag) isGitSource_RevisionAlias() {}
+
+func (*GitSource_Ref) isGitSource_RevisionAlias() {}
+
+// A change under review in Gerrit, the request commit id is the revision of the patchset that was built
+type GerritSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// URI of the Gerrit host, e.g. https://gerrit.example.com
+	HostUri string `protobuf:"bytes,1,opt,name=host_uri,json=hostUri,proto3" json:"host_uri,omitempty"`
+	// name of the Gerrit project
+	Project string `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	// change number or Change-Id
+	Change   string `protobuf:"bytes,3,opt,name=change,proto3" json:"change,omitempty"`
+	Patchset string `protobuf:"bytes,4,opt,name=patchset,proto3" json:"patchset,omitempty"`
+}
+
+func (x *GerritSource) Reset() {
+	*x = GerritSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GerritSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GerritSource) ProtoMessage() {}
+
+func (x *GerritSource) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GerritSource.ProtoReflect.Descriptor instead.
+func (*GerritSource) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GerritSource) GetHostUri() string {
+	if x != nil {
+		return x.HostUri
+	}
+	return ""
+}
+
+func (x *GerritSource) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *GerritSource) GetChange() string {
+	if x != nil {
+		return x.Change
+	}
+	return ""
+}
+
+func (x *GerritSource) GetPatchset() string {
+	if x != nil {
+		return x.Patchset
+	}
+	return ""
+}
+
+// A repository identified by project and name, such as a Cloud Source Repository or a mirror of a non-Git
+// repository. The revision is the request commit id, or an alias when the commit isn't known.
+type CloudRepoSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProjectId string                    `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	RepoName  string                    `protobuf:"bytes,2,opt,name=repo_name,json=repoName,proto3" json:"repo_name,omitempty"`
+	AliasKind CloudRepoSource_AliasKind `protobuf:"varint,3,opt,name=alias_kind,json=aliasKind,proto3,enum=build_collector.v1alpha1.CloudRepoSource_AliasKind" json:"alias_kind,omitempty"`
+	// name of the branch, tag or other alias that was built
+	AliasName string `protobuf:"bytes,4,opt,name=alias_name,json=aliasName,proto3" json:"alias_name,omitempty"`
+}
+
+func (x *CloudRepoSource) Reset() {
+	*x = CloudRepoSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloudRepoSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloudRepoSource) ProtoMessage() {}
+
+func (x *CloudRepoSource) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloudRepoSource.ProtoReflect.Descriptor instead.
+func (*CloudRepoSource) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CloudRepoSource) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *CloudRepoSource) GetRepoName() string {
+	if x != nil {
+		return x.RepoName
+	}
+	return ""
+}
+
+func (x *CloudRepoSource) GetAliasKind() CloudRepoSource_AliasKind {
+	if x != nil {
+		return x.AliasKind
+	}
+	return CloudRepoSource_ALIAS_KIND_UNSPECIFIED
+}
+
+func (x *CloudRepoSource) GetAliasName() string {
+	if x != nil {
+		return x.AliasName
+	}
+	return ""
+}
+
+// An archive of source code, such as an uploaded tarball
+type ArchiveSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// location of the archive
+	Uri string `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	// sha256 digest of the archive
+	Digest *Digest `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+}
+
+func (x *ArchiveSource) Reset() {
+	*x = ArchiveSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArchiveSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveSource) ProtoMessage() {}
+
+func (x *ArchiveSource) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveSource.ProtoReflect.Descriptor instead.
+func (*ArchiveSource) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ArchiveSource) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *ArchiveSource) GetDigest() *Digest {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+type CreateBuildRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The Git repository holding the source code for the artifact(s), required unless another source is specified
+	Repository string `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	// Any generated outputs of the build
+	Artifacts []*Artifact `protobuf:"bytes,2,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	// Commit SHA
+	CommitId string `protobuf:"bytes,3,opt,name=commit_id,json=commitId,proto3" json:"commit_id,omitempty"`
+	// source of the build
+	ProvenanceId string `protobuf:"bytes,4,opt,name=provenance_id,json=provenanceId,proto3" json:"provenance_id,omitempty"`
+	// link to the build logs
+	LogsUri string `protobuf:"bytes,5,opt,name=logs_uri,json=logsUri,proto3" json:"logs_uri,omitempty"`
+	// build creator
+	Creator string `protobuf:"bytes,6,opt,name=creator,proto3" json:"creator,omitempty"`
+	// time the build began
+	BuildStart *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=build_start,json=buildStart,proto3" json:"build_start,omitempty"`
+	// timestamp of when the build ended
+	BuildEnd *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=build_end,json=buildEnd,proto3" json:"build_end,omitempty"`
+	// link to a diff of the changeset
+	CommitUri string `protobuf:"bytes,9,opt,name=commit_uri,json=commitUri,proto3" json:"commit_uri,omitempty"`
+	// key used to detect duplicate submissions of the same build, defaults to the provenance id and commit SHA
+	IdempotencyKey string `protobuf:"bytes,10,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// how to handle a build that has already been recorded with the same idempotency key
+	IdempotencyMode CreateBuildRequest_IdempotencyMode `protobuf:"varint,11,opt,name=idempotency_mode,json=idempotencyMode,proto3,enum=build_collector.v1alpha1.CreateBuildRequest_IdempotencyMode" json:"idempotency_mode,omitempty"`
+	// name of a configured note to record the build against, the collector's default note is used when empty
+	Note string `protobuf:"bytes,12,opt,name=note,proto3" json:"note,omitempty"`
+	// inputs to the build
+	Materials []*Material `protobuf:"bytes,13,rep,name=materials,proto3" json:"materials,omitempty"`
+	// commands run to produce the artifacts
+	Steps []*BuildStep `protobuf:"bytes,14,rep,name=steps,proto3" json:"steps,omitempty"`
+	// the system that ran the build
+	Builder *Builder `protobuf:"bytes,15,opt,name=builder,proto3" json:"builder,omitempty"`
+	// free-form options the build was run with
+	BuildOptions map[string]string `protobuf:"bytes,16,rep,name=build_options,json=buildOptions,proto3" json:"build_options,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// where the source code for the build came from, builds are assumed to be from the Git repository when unset
+	//
+	// Types that are assignable to Source:
+	//	*CreateBuildRequest_Git
+	//	*CreateBuildRequest_Gerrit
+	//	*CreateBuildRequest_CloudRepo
+	//	*CreateBuildRequest_Archive
+	Source isCreateBuildRequest_Source `protobuf_oneof:"source"`
+}
+
+func (x *CreateBuildRequest) Reset() {
+	*x = CreateBuildRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBuildRequest) ProtoMessage() {}
+
+func (x *CreateBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBuildRequest.ProtoReflect.Descriptor instead.
+func (*CreateBuildRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateBuildRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *CreateBuildRequest) GetArtifacts() []*Artifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *CreateBuildRequest) GetCommitId() string {
+	if x != nil {
+		return x.CommitId
+	}
+	return ""
+}
+
+func (x *CreateBuildRequest) GetProvenanceId() string {
+	if x != nil {
+		return x.ProvenanceId
+	}
+	return ""
+}
+
+func (x *CreateBuildRequest) GetLogsUri() string {
+	if x != nil {
+		return x.LogsUri
+	}
+	return ""
+}
+
+func (x *CreateBuildRequest) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *CreateBuildRequest) GetBuildStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BuildStart
+	}
+	return nil
+}
+
+func (x *CreateBuildRequest) GetBuildEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BuildEnd
+	}
+	return nil
+}
+
+func (x *CreateBuildRequest) GetCommitUri() string {
+	if x != nil {
+		return x.CommitUri
+	}
+	return ""
+}
+
+func (x *CreateBuildRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateBuildRequest) GetIdempotencyMode() CreateBuildRequest_IdempotencyMode {
+	if x != nil {
+		return x.IdempotencyMode
+	}
+	return CreateBuildRequest_RETURN_EXISTING
+}
+
+func (x *CreateBuildRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *CreateBuildRequest) GetMaterials() []*Material {
+	if x != nil {
+		return x.Materials
+	}
+	return nil
+}
+
+func (x *CreateBuildRequest) GetSteps() []*BuildStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *CreateBuildRequest) GetBuilder() *Builder {
+	if x != nil {
+		return x.Builder
+	}
+	return nil
+}
+
+func (x *CreateBuildRequest) GetBuildOptions() map[string]string {
+	if x != nil {
+		return x.BuildOptions
+	}
+	return nil
+}
+
+func (m *CreateBuildRequest) GetSource() isCreateBuildRequest_Source {
+	if m != nil {
+		return m.Source
+	}
+	return nil
+}
+
+func (x *CreateBuildRequest) GetGit() *GitSource {
+	if x, ok := x.GetSource().(*CreateBuildRequest_Git); ok {
+		return x.Git
+	}
+	return nil
+}
+
+func (x *CreateBuildRequest) GetGerrit() *GerritSource {
+	if x, ok := x.GetSource().(*CreateBuildRequest_Gerrit); ok {
+		return x.Gerrit
+	}
+	return nil
+}
+
+func (x *CreateBuildRequest) GetCloudRepo() *CloudRepoSource {
+	if x, ok := x.GetSource().(*CreateBuildRequest_CloudRepo); ok {
+		return x.CloudRepo
+	}
+	return nil
+}
+
+func (x *CreateBuildRequest) GetArchive() *ArchiveSource {
+	if x, ok := x.GetSource().(*CreateBuildRequest_Archive); ok {
+		return x.Archive
+	}
+	return nil
+}
+
+type isCreateBuildRequest_Source interface {
+	isCreateBuildRequest_Source()
+}
+
+type CreateBuildRequest_Git struct {
+	Git *GitSource `protobuf:"bytes,17,opt,name=git,proto3,oneof"`
+}
+
+type CreateBuildRequest_Gerrit struct {
+	Gerrit *GerritSource `protobuf:"bytes,18,opt,name=gerrit,proto3,oneof"`
+}
+
+type CreateBuildRequest_CloudRepo struct {
+	CloudRepo *CloudRepoSource `protobuf:"bytes,19,opt,name=cloud_repo,json=cloudRepo,proto3,oneof"`
+}
+
+type CreateBuildRequest_Archive struct {
+	Archive *ArchiveSource `protobuf:"bytes,20,opt,name=archive,proto3,oneof"`
+}
+
+func (*CreateBuildRequest_Git) isCreateBuildRequest_Source() {}
+
+func (*CreateBuildRequest_Gerrit) isCreateBuildRequest_Source() {}
+
+func (*CreateBuildRequest_CloudRepo) isCreateBuildRequest_Source() {}
+
+func (*CreateBuildRequest_Archive) isCreateBuildRequest_Source() {}
+
+type CreateBuildResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unique id of the new build occurrence
+	BuildOccurrenceId string `protobuf:"bytes,1,opt,name=build_occurrence_id,json=buildOccurrenceId,proto3" json:"build_occurrence_id,omitempty"`
+	// true when the build had already been recorded and no new occurrence was created
+	AlreadyExists bool `protobuf:"varint,2,opt,name=already_exists,json=alreadyExists,proto3" json:"already_exists,omitempty"`
+}
+
+func (x *CreateBuildResponse) Reset() {
+	*x = CreateBuildResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateBuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBuildResponse) ProtoMessage() {}
+
+func (x *CreateBuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBuildResponse.ProtoReflect.Descriptor instead.
+func (*CreateBuildResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateBuildResponse) GetBuildOccurrenceId() string {
+	if x != nil {
+		return x.BuildOccurrenceId
+	}
+	return ""
+}
+
+func (x *CreateBuildResponse) GetAlreadyExists() bool {
+	if x != nil {
+		return x.AlreadyExists
+	}
+	return false
+}
+
+type BatchCreateBuildsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The builds to create
+	Builds []*CreateBuildRequest `protobuf:"bytes,1,rep,name=builds,proto3" json:"builds,omitempty"`
+}
+
+func (x *BatchCreateBuildsRequest) Reset() {
+	*x = BatchCreateBuildsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchCreateBuildsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateBuildsRequest) ProtoMessage() {}
+
+func (x *BatchCreateBuildsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateBuildsRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateBuildsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BatchCreateBuildsRequest) GetBuilds() []*CreateBuildRequest {
+	if x != nil {
+		return x.Builds
+	}
+	return nil
+}
+
+type BatchCreateBuildResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unique id of the new build occurrence, set when the build was created
+	BuildOccurrenceId string `protobuf:"bytes,1,opt,name=build_occurrence_id,json=buildOccurrenceId,proto3" json:"build_occurrence_id,omitempty"`
+	// The reason the build could not be created
+	Error *status.Status `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// true when the build had already been recorded and build_occurrence_id is the existing occurrence
+	AlreadyExists bool `protobuf:"varint,3,opt,name=already_exists,json=alreadyExists,proto3" json:"already_exists,omitempty"`
+}
+
+func (x *BatchCreateBuildResult) Reset() {
+	*x = BatchCreateBuildResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchCreateBuildResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateBuildResult) ProtoMessage() {}
+
+func (x *BatchCreateBuildResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateBuildResult.ProtoReflect.Descriptor instead.
+func (*BatchCreateBuildResult) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchCreateBuildResult) GetBuildOccurrenceId() string {
+	if x != nil {
+		return x.BuildOccurrenceId
+	}
+	return ""
+}
+
+func (x *BatchCreateBuildResult) GetError() *status.Status {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *BatchCreateBuildResult) GetAlreadyExists() bool {
+	if x != nil {
+		return x.AlreadyExists
+	}
+	return false
+}
+
+type BatchCreateBuildsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// One result for each requested build, in request order
+	Results []*BatchCreateBuildResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *BatchCreateBuildsResponse) Reset() {
+	*x = BatchCreateBuildsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchCreateBuildsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateBuildsResponse) ProtoMessage() {}
+
+func (x *BatchCreateBuildsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateBuildsResponse.ProtoReflect.Descriptor instead.
+func (*BatchCreateBuildsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BatchCreateBuildsResponse) GetResults() []*BatchCreateBuildResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type UpdateBuildArtifactsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExistingArtifactId string    `protobuf:"bytes,1,opt,name=existing_artifact_id,json=existingArtifactId,proto3" json:"existing_artifact_id,omitempty"`
+	NewArtifact        *Artifact `protobuf:"bytes,2,opt,name=new_artifact,json=newArtifact,proto3" json:"new_artifact,omitempty"`
+	// Unique id of the build occurrence to update, takes precedence over searching by existing_artifact_id
+	BuildOccurrenceId string `protobuf:"bytes,3,opt,name=build_occurrence_id,json=buildOccurrenceId,proto3" json:"build_occurrence_id,omitempty"`
+}
+
+func (x *UpdateBuildArtifactsRequest) Reset() {
+	*x = UpdateBuildArtifactsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateBuildArtifactsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBuildArtifactsRequest) ProtoMessage() {}
+
+func (x *UpdateBuildArtifactsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBuildArtifactsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBuildArtifactsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateBuildArtifactsRequest) GetExistingArtifactId() string {
+	if x != nil {
+		return x.ExistingArtifactId
+	}
+	return ""
+}
+
+func (x *UpdateBuildArtifactsRequest) GetNewArtifact() *Artifact {
+	if x != nil {
+		return x.NewArtifact
+	}
+	return nil
+}
+
+func (x *UpdateBuildArtifactsRequest) GetBuildOccurrenceId() string {
+	if x != nil {
+		return x.BuildOccurrenceId
+	}
+	return ""
+}
+
+type UpdateBuildArtifactsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unique id of the updated build occurrence
+	BuildOccurrenceId string `protobuf:"bytes,1,opt,name=build_occurrence_id,json=buildOccurrenceId,proto3" json:"build_occurrence_id,omitempty"`
+	// How the new artifact was applied to the build occurrence
+	ArtifactStatus UpdateBuildArtifactsResponse_ArtifactStatus `protobuf:"varint,2,opt,name=artifact_status,json=artifactStatus,proto3,enum=build_collector.v1alpha1.UpdateBuildArtifactsResponse_ArtifactStatus" json:"artifact_status,omitempty"`
+}
+
+func (x *UpdateBuildArtifactsResponse) Reset() {
 	*x = UpdateBuildArtifactsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[4]
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateBuildArtifactsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBuildArtifactsResponse) ProtoMessage() {}
+
+func (x *UpdateBuildArtifactsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBuildArtifactsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateBuildArtifactsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateBuildArtifactsResponse) GetBuildOccurrenceId() string {
+	if x != nil {
+		return x.BuildOccurrenceId
+	}
+	return ""
+}
+
+func (x *UpdateBuildArtifactsResponse) GetArtifactStatus() UpdateBuildArtifactsResponse_ArtifactStatus {
+	if x != nil {
+		return x.ArtifactStatus
+	}
+	return UpdateBuildArtifactsResponse_ARTIFACT_STATUS_UNSPECIFIED
+}
+
+type RemoveBuildArtifactRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The artifact to remove, also used to find the build when build_occurrence_id is not set
+	ArtifactId string `protobuf:"bytes,1,opt,name=artifact_id,json=artifactId,proto3" json:"artifact_id,omitempty"`
+	// Unique id of the build occurrence to update
+	BuildOccurrenceId string `protobuf:"bytes,2,opt,name=build_occurrence_id,json=buildOccurrenceId,proto3" json:"build_occurrence_id,omitempty"`
+}
+
+func (x *RemoveBuildArtifactRequest) Reset() {
+	*x = RemoveBuildArtifactRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveBuildArtifactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveBuildArtifactRequest) ProtoMessage() {}
+
+func (x *RemoveBuildArtifactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveBuildArtifactRequest.ProtoReflect.Descriptor instead.
+func (*RemoveBuildArtifactRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RemoveBuildArtifactRequest) GetArtifactId() string {
+	if x != nil {
+		return x.ArtifactId
+	}
+	return ""
+}
+
+func (x *RemoveBuildArtifactRequest) GetBuildOccurrenceId() string {
+	if x != nil {
+		return x.BuildOccurrenceId
+	}
+	return ""
+}
+
+type RemoveBuildArtifactResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unique id of the updated build occurrence
+	BuildOccurrenceId string `protobuf:"bytes,1,opt,name=build_occurrence_id,json=buildOccurrenceId,proto3" json:"build_occurrence_id,omitempty"`
+}
+
+func (x *RemoveBuildArtifactResponse) Reset() {
+	*x = RemoveBuildArtifactResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveBuildArtifactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveBuildArtifactResponse) ProtoMessage() {}
+
+func (x *RemoveBuildArtifactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveBuildArtifactResponse.ProtoReflect.Descriptor instead.
+func (*RemoveBuildArtifactResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RemoveBuildArtifactResponse) GetBuildOccurrenceId() string {
+	if x != nil {
+		return x.BuildOccurrenceId
+	}
+	return ""
+}
+
+type ReplaceBuildArtifactsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// An artifact currently on the build, used to find the build when build_occurrence_id is not set
+	ExistingArtifactId string `protobuf:"bytes,1,opt,name=existing_artifact_id,json=existingArtifactId,proto3" json:"existing_artifact_id,omitempty"`
+	// Unique id of the build occurrence to update
+	BuildOccurrenceId string `protobuf:"bytes,2,opt,name=build_occurrence_id,json=buildOccurrenceId,proto3" json:"build_occurrence_id,omitempty"`
+	// The complete set of artifacts the build should have
+	Artifacts []*Artifact `protobuf:"bytes,3,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+}
+
+func (x *ReplaceBuildArtifactsRequest) Reset() {
+	*x = ReplaceBuildArtifactsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplaceBuildArtifactsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceBuildArtifactsRequest) ProtoMessage() {}
+
+func (x *ReplaceBuildArtifactsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceBuildArtifactsRequest.ProtoReflect.Descriptor instead.
+func (*ReplaceBuildArtifactsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReplaceBuildArtifactsRequest) GetExistingArtifactId() string {
+	if x != nil {
+		return x.ExistingArtifactId
+	}
+	return ""
+}
+
+func (x *ReplaceBuildArtifactsRequest) GetBuildOccurrenceId() string {
+	if x != nil {
+		return x.BuildOccurrenceId
+	}
+	return ""
+}
+
+func (x *ReplaceBuildArtifactsRequest) GetArtifacts() []*Artifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+type ReplaceBuildArtifactsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unique id of the updated build occurrence
+	BuildOccurrenceId string `protobuf:"bytes,1,opt,name=build_occurrence_id,json=buildOccurrenceId,proto3" json:"build_occurrence_id,omitempty"`
+}
+
+func (x *ReplaceBuildArtifactsResponse) Reset() {
+	*x = ReplaceBuildArtifactsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplaceBuildArtifactsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceBuildArtifactsResponse) ProtoMessage() {}
+
+func (x *ReplaceBuildArtifactsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceBuildArtifactsResponse.ProtoReflect.Descriptor instead.
+func (*ReplaceBuildArtifactsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReplaceBuildArtifactsResponse) GetBuildOccurrenceId() string {
+	if x != nil {
+		return x.BuildOccurrenceId
+	}
+	return ""
+}
+
+type GetBuildRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unique id of the build occurrence
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetBuildRequest) Reset() {
+	*x = GetBuildRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuildRequest) ProtoMessage() {}
+
+func (x *GetBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuildRequest.ProtoReflect.Descriptor instead.
+func (*GetBuildRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetBuildRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Build struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unique id of the build occurrence
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The Git repository holding the source code for the artifact(s), as recorded on the occurrence resource
+	Repository string `protobuf:"bytes,2,opt,name=repository,proto3" json:"repository,omitempty"`
+	// Any generated outputs of the build
+	Artifacts []*Artifact `protobuf:"bytes,3,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	// Commit SHA
+	CommitId string `protobuf:"bytes,4,opt,name=commit_id,json=commitId,proto3" json:"commit_id,omitempty"`
+	// source of the build
+	ProvenanceId string `protobuf:"bytes,5,opt,name=provenance_id,json=provenanceId,proto3" json:"provenance_id,omitempty"`
+	// link to the build logs
+	LogsUri string `protobuf:"bytes,6,opt,name=logs_uri,json=logsUri,proto3" json:"logs_uri,omitempty"`
+	// build creator
+	Creator string `protobuf:"bytes,7,opt,name=creator,proto3" json:"creator,omitempty"`
+	// time the build began
+	BuildStart *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=build_start,json=buildStart,proto3" json:"build_start,omitempty"`
+	// timestamp of when the build ended
+	BuildEnd *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=build_end,json=buildEnd,proto3" json:"build_end,omitempty"`
+	// link to a diff of the changeset
+	CommitUri string `protobuf:"bytes,10,opt,name=commit_uri,json=commitUri,proto3" json:"commit_uri,omitempty"`
+	// time the build occurrence was created
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// full name of the note the build occurrence references
+	NoteName string `protobuf:"bytes,12,opt,name=note_name,json=noteName,proto3" json:"note_name,omitempty"`
+	// inputs to the build
+	Materials []*Material `protobuf:"bytes,13,rep,name=materials,proto3" json:"materials,omitempty"`
+	// commands run to produce the artifacts
+	Steps []*BuildStep `protobuf:"bytes,14,rep,name=steps,proto3" json:"steps,omitempty"`
+	// the system that ran the build
+	Builder *Builder `protobuf:"bytes,15,opt,name=builder,proto3" json:"builder,omitempty"`
+	// free-form options the build was run with
+	BuildOptions map[string]string `protobuf:"bytes,16,rep,name=build_options,json=buildOptions,proto3" json:"build_options,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// where the source code for the build came from
+	//
+	// Types that are assignable to Source:
+	//	*Build_Git
+	//	*Build_Gerrit
+	//	*Build_CloudRepo
+	//	*Build_Archive
+	Source isBuild_Source `protobuf_oneof:"source"`
+	// whether the collector changed the submitted build start time
+	BuildStartAdjustment Build_TimestampAdjustment `protobuf:"varint,21,opt,name=build_start_adjustment,json=buildStartAdjustment,proto3,enum=build_collector.v1alpha1.Build_TimestampAdjustment" json:"build_start_adjustment,omitempty"`
+	// whether the collector changed the submitted build end time
+	BuildEndAdjustment Build_TimestampAdjustment `protobuf:"varint,22,opt,name=build_end_adjustment,json=buildEndAdjustment,proto3,enum=build_collector.v1alpha1.Build_TimestampAdjustment" json:"build_end_adjustment,omitempty"`
+	// id of the trusted key that verified the signature on ingested provenance, empty when the build wasn't verified
+	ProvenanceKeyId string `protobuf:"bytes,23,opt,name=provenance_key_id,json=provenanceKeyId,proto3" json:"provenance_key_id,omitempty"`
+	// id of the key the collector signed the build occurrence with, empty when the occurrence isn't signed
+	SignatureKeyId string `protobuf:"bytes,24,opt,name=signature_key_id,json=signatureKeyId,proto3" json:"signature_key_id,omitempty"`
+	// ids of the occurrences of SBOMs attached to the build's artifacts
+	SbomOccurrenceIds []string `protobuf:"bytes,25,rep,name=sbom_occurrence_ids,json=sbomOccurrenceIds,proto3" json:"sbom_occurrence_ids,omitempty"`
+}
+
+func (x *Build) Reset() {
+	*x = Build{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Build) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Build) ProtoMessage() {}
+
+func (x *Build) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Build.ProtoReflect.Descriptor instead.
+func (*Build) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Build) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Build) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *Build) GetArtifacts() []*Artifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *Build) GetCommitId() string {
+	if x != nil {
+		return x.CommitId
+	}
+	return ""
+}
+
+func (x *Build) GetProvenanceId() string {
+	if x != nil {
+		return x.ProvenanceId
+	}
+	return ""
+}
+
+func (x *Build) GetLogsUri() string {
+	if x != nil {
+		return x.LogsUri
+	}
+	return ""
+}
+
+func (x *Build) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *Build) GetBuildStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BuildStart
+	}
+	return nil
+}
+
+func (x *Build) GetBuildEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BuildEnd
+	}
+	return nil
+}
+
+func (x *Build) GetCommitUri() string {
+	if x != nil {
+		return x.CommitUri
+	}
+	return ""
+}
+
+func (x *Build) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Build) GetNoteName() string {
+	if x != nil {
+		return x.NoteName
+	}
+	return ""
+}
+
+func (x *Build) GetMaterials() []*Material {
+	if x != nil {
+		return x.Materials
+	}
+	return nil
+}
+
+func (x *Build) GetSteps() []*BuildStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *Build) GetBuilder() *Builder {
+	if x != nil {
+		return x.Builder
+	}
+	return nil
+}
+
+func (x *Build) GetBuildOptions() map[string]string {
+	if x != nil {
+		return x.BuildOptions
+	}
+	return nil
+}
+
+func (m *Build) GetSource() isBuild_Source {
+	if m != nil {
+		return m.Source
+	}
+	return nil
+}
+
+func (x *Build) GetGit() *GitSource {
+	if x, ok := x.GetSource().(*Build_Git); ok {
+		return x.Git
+	}
+	return nil
+}
+
+func (x *Build) GetGerrit() *GerritSource {
+	if x, ok := x.GetSource().(*Build_Gerrit); ok {
+		return x.Gerrit
+	}
+	return nil
+}
+
+func (x *Build) GetCloudRepo() *CloudRepoSource {
+	if x, ok := x.GetSource().(*Build_CloudRepo); ok {
+		return x.CloudRepo
+	}
+	return nil
+}
+
+func (x *Build) GetArchive() *ArchiveSource {
+	if x, ok := x.GetSource().(*Build_Archive); ok {
+		return x.Archive
+	}
+	return nil
+}
+
+func (x *Build) GetBuildStartAdjustment() Build_TimestampAdjustment {
+	if x != nil {
+		return x.BuildStartAdjustment
+	}
+	return Build_TIMESTAMP_ADJUSTMENT_UNSPECIFIED
+}
+
+func (x *Build) GetBuildEndAdjustment() Build_TimestampAdjustment {
+	if x != nil {
+		return x.BuildEndAdjustment
+	}
+	return Build_TIMESTAMP_ADJUSTMENT_UNSPECIFIED
+}
+
+func (x *Build) GetProvenanceKeyId() string {
+	if x != nil {
+		return x.ProvenanceKeyId
+	}
+	return ""
+}
+
+func (x *Build) GetSignatureKeyId() string {
+	if x != nil {
+		return x.SignatureKeyId
+	}
+	return ""
+}
+
+func (x *Build) GetSbomOccurrenceIds() []string {
+	if x != nil {
+		return x.SbomOccurrenceIds
+	}
+	return nil
+}
+
+type isBuild_Source interface {
+	isBuild_Source()
+}
+
+type Build_Git struct {
+	Git *GitSource `protobuf:"bytes,17,opt,name=git,proto3,oneof"`
+}
+
+type Build_Gerrit struct {
+	Gerrit *GerritSource `protobuf:"bytes,18,opt,name=gerrit,proto3,oneof"`
+}
+
+type Build_CloudRepo struct {
+	CloudRepo *CloudRepoSource `protobuf:"bytes,19,opt,name=cloud_repo,json=cloudRepo,proto3,oneof"`
+}
+
+type Build_Archive struct {
+	Archive *ArchiveSource `protobuf:"bytes,20,opt,name=archive,proto3,oneof"`
+}
+
+func (*Build_Git) isBuild_Source() {}
+
+func (*Build_Gerrit) isBuild_Source() {}
+
+func (*Build_CloudRepo) isBuild_Source() {}
+
+func (*Build_Archive) isBuild_Source() {}
+
+type ListBuildsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only return builds for this Git repository
+	Repository string `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	// Only return builds for this commit SHA
+	CommitId string `protobuf:"bytes,2,opt,name=commit_id,json=commitId,proto3" json:"commit_id,omitempty"`
+	// Only return builds started by this creator
+	Creator string `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
+	// Only return builds that began at or after this time
+	BuildStartAfter *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=build_start_after,json=buildStartAfter,proto3" json:"build_start_after,omitempty"`
+	// Only return builds that began at or before this time
+	BuildStartBefore *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=build_start_before,json=buildStartBefore,proto3" json:"build_start_before,omitempty"`
+	// Only return builds that produced this artifact
+	ArtifactId string `protobuf:"bytes,6,opt,name=artifact_id,json=artifactId,proto3" json:"artifact_id,omitempty"`
+	PageSize   int32  `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken  string `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Only return builds recorded against this configured note, builds from all configured notes are returned when empty
+	Note string `protobuf:"bytes,9,opt,name=note,proto3" json:"note,omitempty"`
+}
+
+func (x *ListBuildsRequest) Reset() {
+	*x = ListBuildsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListBuildsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBuildsRequest) ProtoMessage() {}
+
+func (x *ListBuildsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBuildsRequest.ProtoReflect.Descriptor instead.
+func (*ListBuildsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListBuildsRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *ListBuildsRequest) GetCommitId() string {
+	if x != nil {
+		return x.CommitId
+	}
+	return ""
+}
+
+func (x *ListBuildsRequest) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *ListBuildsRequest) GetBuildStartAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BuildStartAfter
+	}
+	return nil
+}
+
+func (x *ListBuildsRequest) GetBuildStartBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BuildStartBefore
+	}
+	return nil
+}
+
+func (x *ListBuildsRequest) GetArtifactId() string {
+	if x != nil {
+		return x.ArtifactId
+	}
+	return ""
+}
+
+func (x *ListBuildsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBuildsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListBuildsRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type ListBuildsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Builds        []*Build `protobuf:"bytes,1,rep,name=builds,proto3" json:"builds,omitempty"`
+	NextPageToken string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListBuildsResponse) Reset() {
+	*x = ListBuildsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListBuildsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBuildsResponse) ProtoMessage() {}
+
+func (x *ListBuildsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBuildsResponse.ProtoReflect.Descriptor instead.
+func (*ListBuildsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListBuildsResponse) GetBuilds() []*Build {
+	if x != nil {
+		return x.Builds
+	}
+	return nil
+}
+
+func (x *ListBuildsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetBuildProvenanceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unique id of the build occurrence
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetBuildProvenanceRequest) Reset() {
+	*x = GetBuildProvenanceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBuildProvenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuildProvenanceRequest) ProtoMessage() {}
+
+func (x *GetBuildProvenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuildProvenanceRequest.ProtoReflect.Descriptor instead.
+func (*GetBuildProvenanceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetBuildProvenanceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetBuildProvenanceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the SLSA predicate type of the statement, e.g. https://slsa.dev/provenance/v1
+	PredicateType string `protobuf:"bytes,1,opt,name=predicate_type,json=predicateType,proto3" json:"predicate_type,omitempty"`
+	// the in-toto statement recorded for the build
+	Statement *structpb.Struct `protobuf:"bytes,2,opt,name=statement,proto3" json:"statement,omitempty"`
+	// the DSSE envelope the statement was ingested in, unchanged so that its signatures can be verified
+	Envelope *structpb.Struct `protobuf:"bytes,3,opt,name=envelope,proto3" json:"envelope,omitempty"`
+}
+
+func (x *GetBuildProvenanceResponse) Reset() {
+	*x = GetBuildProvenanceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBuildProvenanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuildProvenanceResponse) ProtoMessage() {}
+
+func (x *GetBuildProvenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuildProvenanceResponse.ProtoReflect.Descriptor instead.
+func (*GetBuildProvenanceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetBuildProvenanceResponse) GetPredicateType() string {
+	if x != nil {
+		return x.PredicateType
+	}
+	return ""
+}
+
+func (x *GetBuildProvenanceResponse) GetStatement() *structpb.Struct {
+	if x != nil {
+		return x.Statement
+	}
+	return nil
+}
+
+func (x *GetBuildProvenanceResponse) GetEnvelope() *structpb.Struct {
+	if x != nil {
+		return x.Envelope
+	}
+	return nil
+}
+
+type IngestProvenanceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// an in-toto statement with a SLSA v0.2 or v1 provenance predicate, or a DSSE envelope with the statement as its
+	// payload, as JSON. The document is recorded exactly as it's sent, so a document with credentials that the collector
+	// would redact is rejected. Over HTTP the document is the request body, other fields are query parameters.
+	Document []byte `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	// name of a configured note to record the build against, the collector's default note is used when empty
+	Note string `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	// how to handle a build that has already been recorded, the idempotency key is the provenance invocation id and commit
+	IdempotencyMode CreateBuildRequest_IdempotencyMode `protobuf:"varint,3,opt,name=idempotency_mode,json=idempotencyMode,proto3,enum=build_collector.v1alpha1.CreateBuildRequest_IdempotencyMode" json:"idempotency_mode,omitempty"`
+}
+
+func (x *IngestProvenanceRequest) Reset() {
+	*x = IngestProvenanceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngestProvenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestProvenanceRequest) ProtoMessage() {}
+
+func (x *IngestProvenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestProvenanceRequest.ProtoReflect.Descriptor instead.
+func (*IngestProvenanceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *IngestProvenanceRequest) GetDocument() []byte {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+func (x *IngestProvenanceRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *IngestProvenanceRequest) GetIdempotencyMode() CreateBuildRequest_IdempotencyMode {
+	if x != nil {
+		return x.IdempotencyMode
+	}
+	return CreateBuildRequest_RETURN_EXISTING
+}
+
+type VerifyBuildRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unique id of the build occurrence
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *VerifyBuildRequest) Reset() {
+	*x = VerifyBuildRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyBuildRequest) ProtoMessage() {}
+
+func (x *VerifyBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyBuildRequest.ProtoReflect.Descriptor instead.
+func (*VerifyBuildRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *VerifyBuildRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type VerifyBuildResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// whether the build occurrence was signed by the collector's signing key and is unchanged since it was signed
+	Verified bool `protobuf:"varint,1,opt,name=verified,proto3" json:"verified,omitempty"`
+	// id of the key the build occurrence was signed with
+	KeyId string `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// why the build occurrence couldn't be verified, empty when it was
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *VerifyBuildResponse) Reset() {
+	*x = VerifyBuildResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyBuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyBuildResponse) ProtoMessage() {}
+
+func (x *VerifyBuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyBuildResponse.ProtoReflect.Descriptor instead.
+func (*VerifyBuildResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *VerifyBuildResponse) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *VerifyBuildResponse) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *VerifyBuildResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GetSigningKeyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetSigningKeyRequest) Reset() {
+	*x = GetSigningKeyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UpdateBuildArtifactsResponse) String() string {
+func (x *GetSigningKeyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateBuildArtifactsResponse) ProtoMessage() {}
+func (*GetSigningKeyRequest) ProtoMessage() {}
 
-func (x *UpdateBuildArtifactsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[4]
+func (x *GetSigningKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -350,14 +2606,297 @@ func (x *UpdateBuildArtifactsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateBuildArtifactsResponse.ProtoReflect.Descriptor instead.
-func (*UpdateBuildArtifactsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GetSigningKeyRequest.ProtoReflect.Descriptor instead.
+func (*GetSigningKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *UpdateBuildArtifactsResponse) GetBuildOccurrenceId() string {
+type GetSigningKeyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id recorded with the signatures made by the key
+	KeyId string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// PEM encoded PKIX public key
+	PublicKey string `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// DSSE payload type of the signed build, the signature is over the pre-authentication encoding of this type and
+	// the build's resource uri, note name and details as canonical JSON
+	PayloadType string `protobuf:"bytes,3,opt,name=payload_type,json=payloadType,proto3" json:"payload_type,omitempty"`
+}
+
+func (x *GetSigningKeyResponse) Reset() {
+	*x = GetSigningKeyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSigningKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSigningKeyResponse) ProtoMessage() {}
+
+func (x *GetSigningKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSigningKeyResponse.ProtoReflect.Descriptor instead.
+func (*GetSigningKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetSigningKeyResponse) GetKeyId() string {
 	if x != nil {
-		return x.BuildOccurrenceId
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *GetSigningKeyResponse) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *GetSigningKeyResponse) GetPayloadType() string {
+	if x != nil {
+		return x.PayloadType
+	}
+	return ""
+}
+
+type AttachSbomRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unique id of the build occurrence that produced the artifact
+	BuildId string `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	// id of an artifact on the build, it must include a digest to match against the component the SBOM describes
+	ArtifactId string `protobuf:"bytes,2,opt,name=artifact_id,json=artifactId,proto3" json:"artifact_id,omitempty"`
+	// format of the document, detected from its contents when unspecified
+	Format AttachSbomRequest_Format `protobuf:"varint,3,opt,name=format,proto3,enum=build_collector.v1alpha1.AttachSbomRequest_Format" json:"format,omitempty"`
+	// the SBOM document
+	Document []byte `protobuf:"bytes,4,opt,name=document,proto3" json:"document,omitempty"`
+}
+
+func (x *AttachSbomRequest) Reset() {
+	*x = AttachSbomRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AttachSbomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachSbomRequest) ProtoMessage() {}
+
+func (x *AttachSbomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachSbomRequest.ProtoReflect.Descriptor instead.
+func (*AttachSbomRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AttachSbomRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *AttachSbomRequest) GetArtifactId() string {
+	if x != nil {
+		return x.ArtifactId
+	}
+	return ""
+}
+
+func (x *AttachSbomRequest) GetFormat() AttachSbomRequest_Format {
+	if x != nil {
+		return x.Format
+	}
+	return AttachSbomRequest_FORMAT_UNSPECIFIED
+}
+
+func (x *AttachSbomRequest) GetDocument() []byte {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+type UploadSbomRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Data:
+	//	*UploadSbomRequest_Metadata
+	//	*UploadSbomRequest_Chunk
+	Data isUploadSbomRequest_Data `protobuf_oneof:"data"`
+}
+
+func (x *UploadSbomRequest) Reset() {
+	*x = UploadSbomRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadSbomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadSbomRequest) ProtoMessage() {}
+
+func (x *UploadSbomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadSbomRequest.ProtoReflect.Descriptor instead.
+func (*UploadSbomRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{32}
+}
+
+func (m *UploadSbomRequest) GetData() isUploadSbomRequest_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *UploadSbomRequest) GetMetadata() *AttachSbomRequest {
+	if x, ok := x.GetData().(*UploadSbomRequest_Metadata); ok {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UploadSbomRequest) GetChunk() []byte {
+	if x, ok := x.GetData().(*UploadSbomRequest_Chunk); ok {
+		return x.Chunk
+	}
+	return nil
+}
+
+type isUploadSbomRequest_Data interface {
+	isUploadSbomRequest_Data()
+}
+
+type UploadSbomRequest_Metadata struct {
+	// the build, artifact and format of the SBOM, the document is left empty and sent in the chunks that follow
+	Metadata *AttachSbomRequest `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
+}
+
+type UploadSbomRequest_Chunk struct {
+	// the next part of the SBOM document
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadSbomRequest_Metadata) isUploadSbomRequest_Data() {}
+
+func (*UploadSbomRequest_Chunk) isUploadSbomRequest_Data() {}
+
+type AttachSbomResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unique id of the occurrence the SBOM was recorded as
+	SbomOccurrenceId string `protobuf:"bytes,1,opt,name=sbom_occurrence_id,json=sbomOccurrenceId,proto3" json:"sbom_occurrence_id,omitempty"`
+	// format of the SBOM, as detected when the request didn't specify it
+	Format AttachSbomRequest_Format `protobuf:"varint,2,opt,name=format,proto3,enum=build_collector.v1alpha1.AttachSbomRequest_Format" json:"format,omitempty"`
+	// name of the component the SBOM describes that matched the artifact
+	ComponentName string `protobuf:"bytes,3,opt,name=component_name,json=componentName,proto3" json:"component_name,omitempty"`
+}
+
+func (x *AttachSbomResponse) Reset() {
+	*x = AttachSbomResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AttachSbomResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachSbomResponse) ProtoMessage() {}
+
+func (x *AttachSbomResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1alpha1_build_collector_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachSbomResponse.ProtoReflect.Descriptor instead.
+func (*AttachSbomResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1alpha1_build_collector_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AttachSbomResponse) GetSbomOccurrenceId() string {
+	if x != nil {
+		return x.SbomOccurrenceId
+	}
+	return ""
+}
+
+func (x *AttachSbomResponse) GetFormat() AttachSbomRequest_Format {
+	if x != nil {
+		return x.Format
+	}
+	return AttachSbomRequest_FORMAT_UNSPECIFIED
+}
+
+func (x *AttachSbomResponse) GetComponentName() string {
+	if x != nil {
+		return x.ComponentName
 	}
 	return ""
 }
@@ -370,80 +2909,587 @@ var file_proto_v1alpha1_build_collector_proto_rawDesc = []byte{
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x18, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f,
 	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
 	0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e,
-	0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f,
+	0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
-	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
-	0x30, 0x0a, 0x08, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6e,
-	0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65,
-	0x73, 0x22, 0x82, 0x03, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c,
-	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6f,
-	0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65,
-	0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x40, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69,
-	0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x62, 0x75,
+	0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x38, 0x0a, 0x06, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x10,
+	0x0a, 0x03, 0x68, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x68, 0x65, 0x78,
+	0x22, 0x6a, 0x0a, 0x08, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x12, 0x38, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x9f, 0x01, 0x0a,
+	0x08, 0x4d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x12, 0x46, 0x0a, 0x06, 0x64,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x2e,
+	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x64, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x1a, 0x39, 0x0a, 0x0b, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x82,
+	0x01, 0x0a, 0x09, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x74, 0x65, 0x70, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x61, 0x72, 0x67, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x69, 0x72, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x69, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x77, 0x61, 0x69, 0x74,
+	0x5f, 0x66, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x77, 0x61, 0x69, 0x74,
+	0x46, 0x6f, 0x72, 0x22, 0x33, 0x0a, 0x07, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x5f, 0x0a, 0x09, 0x47, 0x69, 0x74, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12,
+	0x12, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03,
+	0x74, 0x61, 0x67, 0x12, 0x12, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x03, 0x72, 0x65, 0x66, 0x42, 0x10, 0x0a, 0x0e, 0x72, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x22, 0x77, 0x0a, 0x0c, 0x47, 0x65, 0x72,
+	0x72, 0x69, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x68, 0x6f, 0x73,
+	0x74, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x68, 0x6f, 0x73,
+	0x74, 0x55, 0x72, 0x69, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x74, 0x63, 0x68, 0x73,
+	0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x74, 0x63, 0x68, 0x73,
+	0x65, 0x74, 0x22, 0x8c, 0x02, 0x0a, 0x0f, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x65, 0x70, 0x6f,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x52, 0x0a, 0x0a, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x5f, 0x6b, 0x69, 0x6e, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x33, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x2e, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x09, 0x61, 0x6c, 0x69,
+	0x61, 0x73, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x6c, 0x69, 0x61,
+	0x73, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x4a, 0x0a, 0x09, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x4b, 0x69,
+	0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x16, 0x41, 0x4c, 0x49, 0x41, 0x53, 0x5f, 0x4b, 0x49, 0x4e, 0x44,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09,
+	0x0a, 0x05, 0x46, 0x49, 0x58, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x4f, 0x56,
+	0x41, 0x42, 0x4c, 0x45, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x54, 0x48, 0x45, 0x52, 0x10,
+	0x03, 0x22, 0x5b, 0x0a, 0x0d, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x75, 0x72, 0x69, 0x12, 0x38, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0xed,
+	0x09, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x40, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x09, 0x61, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e,
+	0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f,
+	0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x67,
+	0x73, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x67,
+	0x73, 0x55, 0x72, 0x69, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x3b,
+	0x0a, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0a, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x37, 0x0a, 0x09, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x45, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x75,
+	0x72, 0x69, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x55, 0x72, 0x69, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64,
+	0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x67, 0x0a, 0x10,
+	0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x6f, 0x64, 0x65,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3c, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x49, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x6d, 0x61, 0x74,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c,
+	0x52, 0x09, 0x6d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x39, 0x0a, 0x05, 0x73,
+	0x74, 0x65, 0x70, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x74, 0x65, 0x70, 0x52,
+	0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x3b, 0x0a, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65,
+	0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x65, 0x72, 0x12, 0x63, 0x0a, 0x0d, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x37, 0x0a, 0x03, 0x67, 0x69, 0x74, 0x18,
+	0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x47, 0x69, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x03, 0x67, 0x69,
+	0x74, 0x12, 0x40, 0x0a, 0x06, 0x67, 0x65, 0x72, 0x72, 0x69, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x26, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x72,
+	0x72, 0x69, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x06, 0x67, 0x65, 0x72,
+	0x72, 0x69, 0x74, 0x12, 0x4a, 0x0a, 0x0a, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x72, 0x65, 0x70,
+	0x6f, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x48, 0x00, 0x52, 0x09, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x12,
+	0x43, 0x0a, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x27, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x72, 0x63, 0x68,
+	0x69, 0x76, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x07, 0x61, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x1a, 0x3f, 0x0a, 0x11, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4d, 0x0a, 0x0f, 0x49, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x45, 0x54, 0x55,
+	0x52, 0x4e, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x12, 0x0a,
+	0x0e, 0x46, 0x41, 0x49, 0x4c, 0x5f, 0x49, 0x46, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x53, 0x10,
+	0x01, 0x12, 0x11, 0x0a, 0x0d, 0x41, 0x4c, 0x57, 0x41, 0x59, 0x53, 0x5f, 0x43, 0x52, 0x45, 0x41,
+	0x54, 0x45, 0x10, 0x02, 0x42, 0x08, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x6c,
+	0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x6f,
+	0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x11, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x4f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x6c, 0x72, 0x65, 0x61, 0x64, 0x79,
+	0x5f, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x61,
+	0x6c, 0x72, 0x65, 0x61, 0x64, 0x79, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x22, 0x60, 0x0a, 0x18,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x44, 0x0a, 0x06, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x06, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x22, 0x99,
+	0x01, 0x0a, 0x16, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x5f, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x4f, 0x63, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x6c, 0x72, 0x65, 0x61, 0x64, 0x79, 0x5f, 0x65,
+	0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x61, 0x6c, 0x72,
+	0x65, 0x61, 0x64, 0x79, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x22, 0x67, 0x0a, 0x19, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x22, 0xc6, 0x01, 0x0a, 0x1b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x65, 0x78, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x5f,
+	0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x12, 0x65, 0x78, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x41, 0x72, 0x74, 0x69, 0x66,
+	0x61, 0x63, 0x74, 0x49, 0x64, 0x12, 0x45, 0x0a, 0x0c, 0x6e, 0x65, 0x77, 0x5f, 0x61, 0x72, 0x74,
+	0x69, 0x66, 0x61, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x62, 0x75,
 	0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31,
 	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52,
-	0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6f,
-	0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
-	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x76, 0x65,
-	0x6e, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08,
-	0x6c, 0x6f, 0x67, 0x73, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x6c, 0x6f, 0x67, 0x73, 0x55, 0x72, 0x69, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74,
-	0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f,
-	0x72, 0x12, 0x3b, 0x0a, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x52, 0x0a, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x37,
-	0x0a, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x62,
-	0x75, 0x69, 0x6c, 0x64, 0x45, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x55, 0x72, 0x69, 0x22, 0x45, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a,
+	0x0b, 0x6e, 0x65, 0x77, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0x2e, 0x0a, 0x13,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x4f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x22, 0x9d, 0x02, 0x0a,
+	0x1c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69,
+	0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a,
 	0x13, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
 	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x62, 0x75, 0x69, 0x6c,
-	0x64, 0x4f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x22, 0x96, 0x01,
-	0x0a, 0x1b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x74,
-	0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a,
-	0x14, 0x65, 0x78, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61,
-	0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x65, 0x78, 0x69,
-	0x73, 0x74, 0x69, 0x6e, 0x67, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x49, 0x64, 0x12,
-	0x45, 0x0a, 0x0c, 0x6e, 0x65, 0x77, 0x5f, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f,
+	0x64, 0x4f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x6e, 0x0a,
+	0x0f, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x45, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x74,
+	0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x41,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0e, 0x61,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x5d, 0x0a,
+	0x0e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x1f, 0x0a, 0x1b, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x09, 0x0a, 0x05, 0x41, 0x44, 0x44, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x4d,
+	0x45, 0x52, 0x47, 0x45, 0x44, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x41, 0x4c, 0x52, 0x45, 0x41,
+	0x44, 0x59, 0x5f, 0x50, 0x52, 0x45, 0x53, 0x45, 0x4e, 0x54, 0x10, 0x03, 0x22, 0x6d, 0x0a, 0x1a,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66,
+	0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x5f, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x4f,
+	0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x22, 0x4d, 0x0a, 0x1b, 0x52,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61,
+	0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x5f, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x4f, 0x63,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x22, 0xc2, 0x01, 0x0a, 0x1c, 0x52,
+	0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66,
+	0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x65,
+	0x78, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x65, 0x78, 0x69, 0x73, 0x74,
+	0x69, 0x6e, 0x67, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x49, 0x64, 0x12, 0x2e, 0x0a,
+	0x13, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x4f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x40, 0x0a,
+	0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69,
+	0x66, 0x61, 0x63, 0x74, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x22,
+	0x4f, 0x0a, 0x1d, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2e, 0x0a, 0x13, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x4f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64,
+	0x22, 0x21, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0xf9, 0x0b, 0x0a, 0x05, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1e, 0x0a,
+	0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x40, 0x0a,
+	0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69,
+	0x66, 0x61, 0x63, 0x74, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d,
+	0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x49,
+	0x64, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x67, 0x73, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x67, 0x73, 0x55, 0x72, 0x69, 0x12, 0x18, 0x0a, 0x07,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x3b, 0x0a, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x37, 0x0a, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x65, 0x6e, 0x64,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x45, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x55, 0x72, 0x69, 0x12, 0x3b, 0x0a, 0x0b, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x74, 0x65,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x74,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x6d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61,
+	0x6c, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x4d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x52, 0x09, 0x6d, 0x61,
+	0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x39, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73,
+	0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x74, 0x65, 0x70, 0x52, 0x05, 0x73, 0x74, 0x65,
+	0x70, 0x73, 0x12, 0x3b, 0x0a, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x12,
+	0x56, 0x0a, 0x0d, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x37, 0x0a, 0x03, 0x67, 0x69, 0x74, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x47, 0x69, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x03, 0x67, 0x69, 0x74,
+	0x12, 0x40, 0x0a, 0x06, 0x67, 0x65, 0x72, 0x72, 0x69, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x26, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x72, 0x72,
+	0x69, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x06, 0x67, 0x65, 0x72, 0x72,
+	0x69, 0x74, 0x12, 0x4a, 0x0a, 0x0a, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x72, 0x65, 0x70, 0x6f,
+	0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x48, 0x00, 0x52, 0x09, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x43,
+	0x0a, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x27, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x72, 0x63, 0x68, 0x69,
+	0x76, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x07, 0x61, 0x72, 0x63, 0x68,
+	0x69, 0x76, 0x65, 0x12, 0x69, 0x0a, 0x16, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x5f, 0x61, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x15, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x33, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x41, 0x64,
+	0x6a, 0x75, 0x73, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x14, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x41, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x65,
+	0x0a, 0x14, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x65, 0x6e, 0x64, 0x5f, 0x61, 0x64, 0x6a, 0x75,
+	0x73, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x33, 0x2e, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x41, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x12, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x45, 0x6e, 0x64, 0x41, 0x64, 0x6a, 0x75, 0x73,
+	0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61,
+	0x6e, 0x63, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x4b, 0x65, 0x79, 0x49,
+	0x64, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x6b,
+	0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x18, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x4b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x73,
+	0x62, 0x6f, 0x6d, 0x5f, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x19, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x73, 0x62, 0x6f, 0x6d, 0x4f, 0x63,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x73, 0x1a, 0x3f, 0x0a, 0x11, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x57, 0x0a, 0x13,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x41, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x6d,
+	0x65, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x20, 0x54, 0x49, 0x4d, 0x45, 0x53, 0x54, 0x41, 0x4d, 0x50,
+	0x5f, 0x41, 0x44, 0x4a, 0x55, 0x53, 0x54, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x44, 0x45, 0x46,
+	0x41, 0x55, 0x4c, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4c, 0x41, 0x4d,
+	0x50, 0x45, 0x44, 0x10, 0x02, 0x42, 0x08, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22,
+	0xed, 0x02, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x46, 0x0a, 0x11,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x61, 0x66, 0x74, 0x65,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x0f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41,
+	0x66, 0x74, 0x65, 0x72, 0x12, 0x48, 0x0a, 0x12, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x10, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x49, 0x64, 0x12,
+	0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x6f, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x22,
+	0x75, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x06, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f,
 	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x0b, 0x6e, 0x65, 0x77, 0x41, 0x72,
-	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x22, 0x4e, 0x0a, 0x1c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f,
-	0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x11, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x4f, 0x63, 0x63, 0x75, 0x72, 0x72,
-	0x65, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x32, 0xbf, 0x02, 0x0a, 0x0e, 0x42, 0x75, 0x69, 0x6c, 0x64,
-	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x87, 0x01, 0x0a, 0x0b, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x2c, 0x2e, 0x62, 0x75, 0x69, 0x6c,
+	0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x06, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x12, 0x26,
+	0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x2b, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0xaf, 0x01, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64,
+	0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x72, 0x65, 0x64,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x35, 0x0a, 0x09, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x33, 0x0a, 0x08, 0x65, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x65, 0x6e, 0x76,
+	0x65, 0x6c, 0x6f, 0x70, 0x65, 0x22, 0xb2, 0x01, 0x0a, 0x17, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74,
+	0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74,
+	0x65, 0x12, 0x67, 0x0a, 0x10, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3c, 0x2e, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x49, 0x64, 0x65, 0x6d, 0x70, 0x6f,
+	0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70,
+	0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x22, 0x24, 0x0a, 0x12, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x60, 0x0a, 0x13, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x69, 0x66,
+	0x69, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x76, 0x65, 0x72, 0x69, 0x66,
+	0x69, 0x65, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x22, 0x16, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67,
+	0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x70, 0x0a, 0x15, 0x47, 0x65,
+	0x74, 0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x79, 0x70, 0x65, 0x22, 0xa3, 0x02, 0x0a,
+	0x11, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x53, 0x62, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x1f, 0x0a,
+	0x0b, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x49, 0x64, 0x12, 0x4a,
+	0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x32,
+	0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68,
+	0x53, 0x62, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x64, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x6a, 0x0a, 0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x12, 0x16, 0x0a, 0x12, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x59, 0x43, 0x4c,
+	0x4f, 0x4e, 0x45, 0x44, 0x58, 0x5f, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d,
+	0x43, 0x59, 0x43, 0x4c, 0x4f, 0x4e, 0x45, 0x44, 0x58, 0x5f, 0x58, 0x4d, 0x4c, 0x10, 0x02, 0x12,
+	0x0d, 0x0a, 0x09, 0x53, 0x50, 0x44, 0x58, 0x5f, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x03, 0x12, 0x12,
+	0x0a, 0x0e, 0x53, 0x50, 0x44, 0x58, 0x5f, 0x54, 0x41, 0x47, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45,
+	0x10, 0x04, 0x22, 0x7e, 0x0a, 0x11, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x62, 0x6f, 0x6d,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x49, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x62, 0x75, 0x69, 0x6c,
 	0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x53, 0x62, 0x6f, 0x6d, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x16, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x48, 0x00, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0xb5, 0x01, 0x0a, 0x12, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x53, 0x62, 0x6f,
+	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x62, 0x6f,
+	0x6d, 0x5f, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x62, 0x6f, 0x6d, 0x4f, 0x63, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x4a, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x32, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x53, 0x62, 0x6f, 0x6d, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6d,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x32, 0xd2, 0x0f, 0x0a, 0x0e, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x87, 0x01,
+	0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x2c, 0x2e,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x15, 0x3a, 0x01, 0x2a, 0x22, 0x10, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x12, 0xa5, 0x01, 0x0a, 0x11, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x12, 0x32, 0x2e,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x33, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x27, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x21, 0x3a, 0x01,
+	0x2a, 0x22, 0x1c, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x73, 0x3a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12,
+	0xa2, 0x01, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x12, 0x35, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x36, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x3a,
+	0x01, 0x2a, 0x1a, 0x10, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x73, 0x12, 0xae, 0x01, 0x0a, 0x13, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0x34, 0x2e, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x35, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2a, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x24, 0x3a, 0x01, 0x2a, 0x22, 0x1f, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x3a, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x41, 0x72, 0x74,
+	0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0xb6, 0x01, 0x0a, 0x15, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63,
+	0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x12,
+	0x36, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61,
+	0x63, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x37, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f,
 	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x22, 0x10,
+	0x61, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x2c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x26, 0x3a, 0x01, 0x2a, 0x22, 0x21, 0x2f, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x3a, 0x72, 0x65,
+	0x70, 0x6c, 0x61, 0x63, 0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x12, 0x75,
+	0x0a, 0x08, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x29, 0x2e, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15,
+	0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73,
+	0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x81, 0x01, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x73, 0x12, 0x2b, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2c, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x12, 0x10, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x12, 0xa9, 0x01, 0x0a, 0x12, 0x47, 0x65,
+	0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x33, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61,
+	0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x28, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x22, 0x12, 0x20, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x70, 0x72, 0x6f, 0x76, 0x65,
+	0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0xa9, 0x01, 0x0a, 0x10, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74,
+	0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x31, 0x2e, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x76,
+	0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x33, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x2d, 0x3a, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x21,
 	0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73,
-	0x3a, 0x01, 0x2a, 0x12, 0xa2, 0x01, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x75,
-	0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x12, 0x35, 0x2e, 0x62,
+	0x3a, 0x69, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x96, 0x01, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x12, 0x2c, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2d, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2a,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x24, 0x12, 0x22, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x8f, 0x01, 0x0a, 0x0d, 0x47,
+	0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x12, 0x2e, 0x2e, 0x62,
 	0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x75,
-	0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x36, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61,
-	0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x15, 0x1a, 0x10, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x62,
-	0x75, 0x69, 0x6c, 0x64, 0x73, 0x3a, 0x01, 0x2a, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x6f, 0x64, 0x65, 0x2f, 0x63, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x2d, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x69,
+	0x6e, 0x67, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x69,
+	0x6e, 0x67, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1d, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2f, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x2d, 0x6b, 0x65, 0x79, 0x12, 0x95, 0x01, 0x0a,
+	0x0a, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x53, 0x62, 0x6f, 0x6d, 0x12, 0x2b, 0x2e, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x53, 0x62, 0x6f,
+	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x53, 0x62, 0x6f, 0x6d, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x26, 0x3a, 0x01,
+	0x2a, 0x22, 0x21, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x73, 0x2f, 0x7b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x73,
+	0x62, 0x6f, 0x6d, 0x73, 0x12, 0x69, 0x0a, 0x0a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x62,
+	0x6f, 0x6d, 0x12, 0x2b, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x53, 0x62, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2c, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63,
+	0x68, 0x53, 0x62, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x42,
+	0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x6f,
+	0x64, 0x65, 0x2f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2d, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -458,29 +3504,132 @@ func file_proto_v1alpha1_build_collector_proto_rawDescGZIP() []byte {
 	return file_proto_v1alpha1_build_collector_proto_rawDescData
 }
 
-var file_proto_v1alpha1_build_collector_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_v1alpha1_build_collector_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_proto_v1alpha1_build_collector_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_proto_v1alpha1_build_collector_proto_goTypes = []interface{}{
-	(*Artifact)(nil),                     // 0: build_collector.v1alpha1.Artifact
-	(*CreateBuildRequest)(nil),           // 1: build_collector.v1alpha1.CreateBuildRequest
-	(*CreateBuildResponse)(nil),          // 2: build_collector.v1alpha1.CreateBuildResponse
-	(*UpdateBuildArtifactsRequest)(nil),  // 3: build_collector.v1alpha1.UpdateBuildArtifactsRequest
-	(*UpdateBuildArtifactsResponse)(nil), // 4: build_collector.v1alpha1.UpdateBuildArtifactsResponse
-	(*timestamppb.Timestamp)(nil),        // 5: google.protobuf.Timestamp
+	(CloudRepoSource_AliasKind)(0),                   // 0: build_collector.v1alpha1.CloudRepoSource.AliasKind
+	(CreateBuildRequest_IdempotencyMode)(0),          // 1: build_collector.v1alpha1.CreateBuildRequest.IdempotencyMode
+	(UpdateBuildArtifactsResponse_ArtifactStatus)(0), // 2: build_collector.v1alpha1.UpdateBuildArtifactsResponse.ArtifactStatus
+	(Build_TimestampAdjustment)(0),                   // 3: build_collector.v1alpha1.Build.TimestampAdjustment
+	(AttachSbomRequest_Format)(0),                    // 4: build_collector.v1alpha1.AttachSbomRequest.Format
+	(*Digest)(nil),                                   // 5: build_collector.v1alpha1.Digest
+	(*Artifact)(nil),                                 // 6: build_collector.v1alpha1.Artifact
+	(*Material)(nil),                                 // 7: build_collector.v1alpha1.Material
+	(*BuildStep)(nil),                                // 8: build_collector.v1alpha1.BuildStep
+	(*Builder)(nil),                                  // 9: build_collector.v1alpha1.Builder
+	(*GitSource)(nil),                                // 10: build_collector.v1alpha1.GitSource
+	(*GerritSource)(nil),                             // 11: build_collector.v1alpha1.GerritSource
+	(*CloudRepoSource)(nil),                          // 12: build_collector.v1alpha1.CloudRepoSource
+	(*ArchiveSource)(nil),                            // 13: build_collector.v1alpha1.ArchiveSource
+	(*CreateBuildRequest)(nil),                       // 14: build_collector.v1alpha1.CreateBuildRequest
+	(*CreateBuildResponse)(nil),                      // 15: build_collector.v1alpha1.CreateBuildResponse
+	(*BatchCreateBuildsRequest)(nil),                 // 16: build_collector.v1alpha1.BatchCreateBuildsRequest
+	(*BatchCreateBuildResult)(nil),                   // 17: build_collector.v1alpha1.BatchCreateBuildResult
+	(*BatchCreateBuildsResponse)(nil),                // 18: build_collector.v1alpha1.BatchCreateBuildsResponse
+	(*UpdateBuildArtifactsRequest)(nil),              // 19: build_collector.v1alpha1.UpdateBuildArtifactsRequest
+	(*UpdateBuildArtifactsResponse)(nil),             // 20: build_collector.v1alpha1.UpdateBuildArtifactsResponse
+	(*RemoveBuildArtifactRequest)(nil),               // 21: build_collector.v1alpha1.RemoveBuildArtifactRequest
+	(*RemoveBuildArtifactResponse)(nil),              // 22: build_collector.v1alpha1.RemoveBuildArtifactResponse
+	(*ReplaceBuildArtifactsRequest)(nil),             // 23: build_collector.v1alpha1.ReplaceBuildArtifactsRequest
+	(*ReplaceBuildArtifactsResponse)(nil),            // 24: build_collector.v1alpha1.ReplaceBuildArtifactsResponse
+	(*GetBuildRequest)(nil),                          // 25: build_collector.v1alpha1.GetBuildRequest
+	(*Build)(nil),                                    // 26: build_collector.v1alpha1.Build
+	(*ListBuildsRequest)(nil),                        // 27: build_collector.v1alpha1.ListBuildsRequest
+	(*ListBuildsResponse)(nil),                       // 28: build_collector.v1alpha1.ListBuildsResponse
+	(*GetBuildProvenanceRequest)(nil),                // 29: build_collector.v1alpha1.GetBuildProvenanceRequest
+	(*GetBuildProvenanceResponse)(nil),               // 30: build_collector.v1alpha1.GetBuildProvenanceResponse
+	(*IngestProvenanceRequest)(nil),                  // 31: build_collector.v1alpha1.IngestProvenanceRequest
+	(*VerifyBuildRequest)(nil),                       // 32: build_collector.v1alpha1.VerifyBuildRequest
+	(*VerifyBuildResponse)(nil),                      // 33: build_collector.v1alpha1.VerifyBuildResponse
+	(*GetSigningKeyRequest)(nil),                     // 34: build_collector.v1alpha1.GetSigningKeyRequest
+	(*GetSigningKeyResponse)(nil),                    // 35: build_collector.v1alpha1.GetSigningKeyResponse
+	(*AttachSbomRequest)(nil),                        // 36: build_collector.v1alpha1.AttachSbomRequest
+	(*UploadSbomRequest)(nil),                        // 37: build_collector.v1alpha1.UploadSbomRequest
+	(*AttachSbomResponse)(nil),                       // 38: build_collector.v1alpha1.AttachSbomResponse
+	nil,                                              // 39: build_collector.v1alpha1.Material.DigestEntry
+	nil,                                              // 40: build_collector.v1alpha1.CreateBuildRequest.BuildOptionsEntry
+	nil,                                              // 41: build_collector.v1alpha1.Build.BuildOptionsEntry
+	(*timestamppb.Timestamp)(nil),                    // 42: google.protobuf.Timestamp
+	(*status.Status)(nil),                            // 43: google.rpc.Status
+	(*structpb.Struct)(nil),                          // 44: google.protobuf.Struct
 }
 var file_proto_v1alpha1_build_collector_proto_depIdxs = []int32{
-	0, // 0: build_collector.v1alpha1.CreateBuildRequest.artifacts:type_name -> build_collector.v1alpha1.Artifact
-	5, // 1: build_collector.v1alpha1.CreateBuildRequest.build_start:type_name -> google.protobuf.Timestamp
-	5, // 2: build_collector.v1alpha1.CreateBuildRequest.build_end:type_name -> google.protobuf.Timestamp
-	0, // 3: build_collector.v1alpha1.UpdateBuildArtifactsRequest.new_artifact:type_name -> build_collector.v1alpha1.Artifact
-	1, // 4: build_collector.v1alpha1.BuildCollector.CreateBuild:input_type -> build_collector.v1alpha1.CreateBuildRequest
-	3, // 5: build_collector.v1alpha1.BuildCollector.UpdateBuildArtifacts:input_type -> build_collector.v1alpha1.UpdateBuildArtifactsRequest
-	2, // 6: build_collector.v1alpha1.BuildCollector.CreateBuild:output_type -> build_collector.v1alpha1.CreateBuildResponse
-	4, // 7: build_collector.v1alpha1.BuildCollector.UpdateBuildArtifacts:output_type -> build_collector.v1alpha1.UpdateBuildArtifactsResponse
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	5,  // 0: build_collector.v1alpha1.Artifact.digest:type_name -> build_collector.v1alpha1.Digest
+	39, // 1: build_collector.v1alpha1.Material.digest:type_name -> build_collector.v1alpha1.Material.DigestEntry
+	0,  // 2: build_collector.v1alpha1.CloudRepoSource.alias_kind:type_name -> build_collector.v1alpha1.CloudRepoSource.AliasKind
+	5,  // 3: build_collector.v1alpha1.ArchiveSource.digest:type_name -> build_collector.v1alpha1.Digest
+	6,  // 4: build_collector.v1alpha1.CreateBuildRequest.artifacts:type_name -> build_collector.v1alpha1.Artifact
+	42, // 5: build_collector.v1alpha1.CreateBuildRequest.build_start:type_name -> google.protobuf.Timestamp
+	42, // 6: build_collector.v1alpha1.CreateBuildRequest.build_end:type_name -> google.protobuf.Timestamp
+	1,  // 7: build_collector.v1alpha1.CreateBuildRequest.idempotency_mode:type_name -> build_collector.v1alpha1.CreateBuildRequest.IdempotencyMode
+	7,  // 8: build_collector.v1alpha1.CreateBuildRequest.materials:type_name -> build_collector.v1alpha1.Material
+	8,  // 9: build_collector.v1alpha1.CreateBuildRequest.steps:type_name -> build_collector.v1alpha1.BuildStep
+	9,  // 10: build_collector.v1alpha1.CreateBuildRequest.builder:type_name -> build_collector.v1alpha1.Builder
+	40, // 11: build_collector.v1alpha1.CreateBuildRequest.build_options:type_name -> build_collector.v1alpha1.CreateBuildRequest.BuildOptionsEntry
+	10, // 12: build_collector.v1alpha1.CreateBuildRequest.git:type_name -> build_collector.v1alpha1.GitSource
+	11, // 13: build_collector.v1alpha1.CreateBuildRequest.gerrit:type_name -> build_collector.v1alpha1.GerritSource
+	12, // 14: build_collector.v1alpha1.CreateBuildRequest.cloud_repo:type_name -> build_collector.v1alpha1.CloudRepoSource
+	13, // 15: build_collector.v1alpha1.CreateBuildRequest.archive:type_name -> build_collector.v1alpha1.ArchiveSource
+	14, // 16: build_collector.v1alpha1.BatchCreateBuildsRequest.builds:type_name -> build_collector.v1alpha1.CreateBuildRequest
+	43, // 17: build_collector.v1alpha1.BatchCreateBuildResult.error:type_name -> google.rpc.Status
+	17, // 18: build_collector.v1alpha1.BatchCreateBuildsResponse.results:type_name -> build_collector.v1alpha1.BatchCreateBuildResult
+	6,  // 19: build_collector.v1alpha1.UpdateBuildArtifactsRequest.new_artifact:type_name -> build_collector.v1alpha1.Artifact
+	2,  // 20: build_collector.v1alpha1.UpdateBuildArtifactsResponse.artifact_status:type_name -> build_collector.v1alpha1.UpdateBuildArtifactsResponse.ArtifactStatus
+	6,  // 21: build_collector.v1alpha1.ReplaceBuildArtifactsRequest.artifacts:type_name -> build_collector.v1alpha1.Artifact
+	6,  // 22: build_collector.v1alpha1.Build.artifacts:type_name -> build_collector.v1alpha1.Artifact
+	42, // 23: build_collector.v1alpha1.Build.build_start:type_name -> google.protobuf.Timestamp
+	42, // 24: build_collector.v1alpha1.Build.build_end:type_name -> google.protobuf.Timestamp
+	42, // 25: build_collector.v1alpha1.Build.create_time:type_name -> google.protobuf.Timestamp
+	7,  // 26: build_collector.v1alpha1.Build.materials:type_name -> build_collector.v1alpha1.Material
+	8,  // 27: build_collector.v1alpha1.Build.steps:type_name -> build_collector.v1alpha1.BuildStep
+	9,  // 28: build_collector.v1alpha1.Build.builder:type_name -> build_collector.v1alpha1.Builder
+	41, // 29: build_collector.v1alpha1.Build.build_options:type_name -> build_collector.v1alpha1.Build.BuildOptionsEntry
+	10, // 30: build_collector.v1alpha1.Build.git:type_name -> build_collector.v1alpha1.GitSource
+	11, // 31: build_collector.v1alpha1.Build.gerrit:type_name -> build_collector.v1alpha1.GerritSource
+	12, // 32: build_collector.v1alpha1.Build.cloud_repo:type_name -> build_collector.v1alpha1.CloudRepoSource
+	13, // 33: build_collector.v1alpha1.Build.archive:type_name -> build_collector.v1alpha1.ArchiveSource
+	3,  // 34: build_collector.v1alpha1.Build.build_start_adjustment:type_name -> build_collector.v1alpha1.Build.TimestampAdjustment
+	3,  // 35: build_collector.v1alpha1.Build.build_end_adjustment:type_name -> build_collector.v1alpha1.Build.TimestampAdjustment
+	42, // 36: build_collector.v1alpha1.ListBuildsRequest.build_start_after:type_name -> google.protobuf.Timestamp
+	42, // 37: build_collector.v1alpha1.ListBuildsRequest.build_start_before:type_name -> google.protobuf.Timestamp
+	26, // 38: build_collector.v1alpha1.ListBuildsResponse.builds:type_name -> build_collector.v1alpha1.Build
+	44, // 39: build_collector.v1alpha1.GetBuildProvenanceResponse.statement:type_name -> google.protobuf.Struct
+	44, // 40: build_collector.v1alpha1.GetBuildProvenanceResponse.envelope:type_name -> google.protobuf.Struct
+	1,  // 41: build_collector.v1alpha1.IngestProvenanceRequest.idempotency_mode:type_name -> build_collector.v1alpha1.CreateBuildRequest.IdempotencyMode
+	4,  // 42: build_collector.v1alpha1.AttachSbomRequest.format:type_name -> build_collector.v1alpha1.AttachSbomRequest.Format
+	36, // 43: build_collector.v1alpha1.UploadSbomRequest.metadata:type_name -> build_collector.v1alpha1.AttachSbomRequest
+	4,  // 44: build_collector.v1alpha1.AttachSbomResponse.format:type_name -> build_collector.v1alpha1.AttachSbomRequest.Format
+	14, // 45: build_collector.v1alpha1.BuildCollector.CreateBuild:input_type -> build_collector.v1alpha1.CreateBuildRequest
+	16, // 46: build_collector.v1alpha1.BuildCollector.BatchCreateBuilds:input_type -> build_collector.v1alpha1.BatchCreateBuildsRequest
+	19, // 47: build_collector.v1alpha1.BuildCollector.UpdateBuildArtifacts:input_type -> build_collector.v1alpha1.UpdateBuildArtifactsRequest
+	21, // 48: build_collector.v1alpha1.BuildCollector.RemoveBuildArtifact:input_type -> build_collector.v1alpha1.RemoveBuildArtifactRequest
+	23, // 49: build_collector.v1alpha1.BuildCollector.ReplaceBuildArtifacts:input_type -> build_collector.v1alpha1.ReplaceBuildArtifactsRequest
+	25, // 50: build_collector.v1alpha1.BuildCollector.GetBuild:input_type -> build_collector.v1alpha1.GetBuildRequest
+	27, // 51: build_collector.v1alpha1.BuildCollector.ListBuilds:input_type -> build_collector.v1alpha1.ListBuildsRequest
+	29, // 52: build_collector.v1alpha1.BuildCollector.GetBuildProvenance:input_type -> build_collector.v1alpha1.GetBuildProvenanceRequest
+	31, // 53: build_collector.v1alpha1.BuildCollector.IngestProvenance:input_type -> build_collector.v1alpha1.IngestProvenanceRequest
+	32, // 54: build_collector.v1alpha1.BuildCollector.VerifyBuild:input_type -> build_collector.v1alpha1.VerifyBuildRequest
+	34, // 55: build_collector.v1alpha1.BuildCollector.GetSigningKey:input_type -> build_collector.v1alpha1.GetSigningKeyRequest
+	36, // 56: build_collector.v1alpha1.BuildCollector.AttachSbom:input_type -> build_collector.v1alpha1.AttachSbomRequest
+	37, // 57: build_collector.v1alpha1.BuildCollector.UploadSbom:input_type -> build_collector.v1alpha1.UploadSbomRequest
+	15, // 58: build_collector.v1alpha1.BuildCollector.CreateBuild:output_type -> build_collector.v1alpha1.CreateBuildResponse
+	18, // 59: build_collector.v1alpha1.BuildCollector.BatchCreateBuilds:output_type -> build_collector.v1alpha1.BatchCreateBuildsResponse
+	20, // 60: build_collector.v1alpha1.BuildCollector.UpdateBuildArtifacts:output_type -> build_collector.v1alpha1.UpdateBuildArtifactsResponse
+	22, // 61: build_collector.v1alpha1.BuildCollector.RemoveBuildArtifact:output_type -> build_collector.v1alpha1.RemoveBuildArtifactResponse
+	24, // 62: build_collector.v1alpha1.BuildCollector.ReplaceBuildArtifacts:output_type -> build_collector.v1alpha1.ReplaceBuildArtifactsResponse
+	26, // 63: build_collector.v1alpha1.BuildCollector.GetBuild:output_type -> build_collector.v1alpha1.Build
+	28, // 64: build_collector.v1alpha1.BuildCollector.ListBuilds:output_type -> build_collector.v1alpha1.ListBuildsResponse
+	30, // 65: build_collector.v1alpha1.BuildCollector.GetBuildProvenance:output_type -> build_collector.v1alpha1.GetBuildProvenanceResponse
+	15, // 66: build_collector.v1alpha1.BuildCollector.IngestProvenance:output_type -> build_collector.v1alpha1.CreateBuildResponse
+	33, // 67: build_collector.v1alpha1.BuildCollector.VerifyBuild:output_type -> build_collector.v1alpha1.VerifyBuildResponse
+	35, // 68: build_collector.v1alpha1.BuildCollector.GetSigningKey:output_type -> build_collector.v1alpha1.GetSigningKeyResponse
+	38, // 69: build_collector.v1alpha1.BuildCollector.AttachSbom:output_type -> build_collector.v1alpha1.AttachSbomResponse
+	38, // 70: build_collector.v1alpha1.BuildCollector.UploadSbom:output_type -> build_collector.v1alpha1.AttachSbomResponse
+	58, // [58:71] is the sub-list for method output_type
+	45, // [45:58] is the sub-list for method input_type
+	45, // [45:45] is the sub-list for extension type_name
+	45, // [45:45] is the sub-list for extension extendee
+	0,  // [0:45] is the sub-list for field type_name
 }
 
 func init() { file_proto_v1alpha1_build_collector_proto_init() }
@@ -490,6 +3639,18 @@ func file_proto_v1alpha1_build_collector_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_proto_v1alpha1_build_collector_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Digest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Artifact); i {
 			case 0:
 				return &v.state
@@ -501,7 +3662,91 @@ func file_proto_v1alpha1_build_collector_proto_init() {
 				return nil
 			}
 		}
-		file_proto_v1alpha1_build_collector_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_v1alpha1_build_collector_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Material); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BuildStep); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Builder); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GitSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GerritSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloudRepoSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArchiveSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateBuildRequest); i {
 			case 0:
 				return &v.state
@@ -513,7 +3758,7 @@ func file_proto_v1alpha1_build_collector_proto_init() {
 				return nil
 			}
 		}
-		file_proto_v1alpha1_build_collector_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_v1alpha1_build_collector_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateBuildResponse); i {
 			case 0:
 				return &v.state
@@ -525,7 +3770,43 @@ func file_proto_v1alpha1_build_collector_proto_init() {
 				return nil
 			}
 		}
-		file_proto_v1alpha1_build_collector_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_v1alpha1_build_collector_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchCreateBuildsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchCreateBuildResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchCreateBuildsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateBuildArtifactsRequest); i {
 			case 0:
 				return &v.state
@@ -537,7 +3818,7 @@ func file_proto_v1alpha1_build_collector_proto_init() {
 				return nil
 			}
 		}
-		file_proto_v1alpha1_build_collector_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_v1alpha1_build_collector_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateBuildArtifactsResponse); i {
 			case 0:
 				return &v.state
@@ -549,19 +3830,257 @@ func file_proto_v1alpha1_build_collector_proto_init() {
 				return nil
 			}
 		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveBuildArtifactRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveBuildArtifactResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplaceBuildArtifactsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplaceBuildArtifactsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBuildRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Build); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListBuildsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListBuildsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBuildProvenanceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBuildProvenanceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngestProvenanceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyBuildRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyBuildResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSigningKeyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSigningKeyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttachSbomRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadSbomRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_v1alpha1_build_collector_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttachSbomResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_proto_v1alpha1_build_collector_proto_msgTypes[5].OneofWrappers = []interface{}{
+		(*GitSource_Branch)(nil),
+		(*GitSource_Tag)(nil),
+		(*GitSource_Ref)(nil),
+	}
+	file_proto_v1alpha1_build_collector_proto_msgTypes[9].OneofWrappers = []interface{}{
+		(*CreateBuildRequest_Git)(nil),
+		(*CreateBuildRequest_Gerrit)(nil),
+		(*CreateBuildRequest_CloudRepo)(nil),
+		(*CreateBuildRequest_Archive)(nil),
+	}
+	file_proto_v1alpha1_build_collector_proto_msgTypes[21].OneofWrappers = []interface{}{
+		(*Build_Git)(nil),
+		(*Build_Gerrit)(nil),
+		(*Build_CloudRepo)(nil),
+		(*Build_Archive)(nil),
+	}
+	file_proto_v1alpha1_build_collector_proto_msgTypes[32].OneofWrappers = []interface{}{
+		(*UploadSbomRequest_Metadata)(nil),
+		(*UploadSbomRequest_Chunk)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_v1alpha1_build_collector_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      5,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_v1alpha1_build_collector_proto_goTypes,
 		DependencyIndexes: file_proto_v1alpha1_build_collector_proto_depIdxs,
+		EnumInfos:         file_proto_v1alpha1_build_collector_proto_enumTypes,
 		MessageInfos:      file_proto_v1alpha1_build_collector_proto_msgTypes,
 	}.Build()
 	File_proto_v1alpha1_build_collector_proto = out.File
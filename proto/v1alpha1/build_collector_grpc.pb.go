@@ -19,7 +19,22 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type BuildCollectorClient interface {
 	CreateBuild(ctx context.Context, in *CreateBuildRequest, opts ...grpc.CallOption) (*CreateBuildResponse, error)
+	BatchCreateBuilds(ctx context.Context, in *BatchCreateBuildsRequest, opts ...grpc.CallOption) (*BatchCreateBuildsResponse, error)
 	UpdateBuildArtifacts(ctx context.Context, in *UpdateBuildArtifactsRequest, opts ...grpc.CallOption) (*UpdateBuildArtifactsResponse, error)
+	RemoveBuildArtifact(ctx context.Context, in *RemoveBuildArtifactRequest, opts ...grpc.CallOption) (*RemoveBuildArtifactResponse, error)
+	ReplaceBuildArtifacts(ctx context.Context, in *ReplaceBuildArtifactsRequest, opts ...grpc.CallOption) (*ReplaceBuildArtifactsResponse, error)
+	GetBuild(ctx context.Context, in *GetBuildRequest, opts ...grpc.CallOption) (*Build, error)
+	ListBuilds(ctx context.Context, in *ListBuildsRequest, opts ...grpc.CallOption) (*ListBuildsResponse, error)
+	GetBuildProvenance(ctx context.Context, in *GetBuildProvenanceRequest, opts ...grpc.CallOption) (*GetBuildProvenanceResponse, error)
+	IngestProvenance(ctx context.Context, in *IngestProvenanceRequest, opts ...grpc.CallOption) (*CreateBuildResponse, error)
+	VerifyBuild(ctx context.Context, in *VerifyBuildRequest, opts ...grpc.CallOption) (*VerifyBuildResponse, error)
+	// GetSigningKey returns the public key that build occurrences are signed with, so that they can be verified
+	// outside of the collector
+	GetSigningKey(ctx context.Context, in *GetSigningKeyRequest, opts ...grpc.CallOption) (*GetSigningKeyResponse, error)
+	AttachSbom(ctx context.Context, in *AttachSbomRequest, opts ...grpc.CallOption) (*AttachSbomResponse, error)
+	// UploadSbom attaches an SBOM that's too large to send in a single message. The first message on the stream sets
+	// the build, artifact and format, and the rest carry the document in chunks.
+	UploadSbom(ctx context.Context, opts ...grpc.CallOption) (BuildCollector_UploadSbomClient, error)
 }
 
 type buildCollectorClient struct {
@@ -39,6 +54,15 @@ func (c *buildCollectorClient) CreateBuild(ctx context.Context, in *CreateBuildR
 	return out, nil
 }
 
+func (c *buildCollectorClient) BatchCreateBuilds(ctx context.Context, in *BatchCreateBuildsRequest, opts ...grpc.CallOption) (*BatchCreateBuildsResponse, error) {
+	out := new(BatchCreateBuildsResponse)
+	err := c.cc.Invoke(ctx, "/build_collector.v1alpha1.BuildCollector/BatchCreateBuilds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *buildCollectorClient) UpdateBuildArtifacts(ctx context.Context, in *UpdateBuildArtifactsRequest, opts ...grpc.CallOption) (*UpdateBuildArtifactsResponse, error) {
 	out := new(UpdateBuildArtifactsResponse)
 	err := c.cc.Invoke(ctx, "/build_collector.v1alpha1.BuildCollector/UpdateBuildArtifacts", in, out, opts...)
@@ -48,12 +72,142 @@ func (c *buildCollectorClient) UpdateBuildArtifacts(ctx context.Context, in *Upd
 	return out, nil
 }
 
+func (c *buildCollectorClient) RemoveBuildArtifact(ctx context.Context, in *RemoveBuildArtifactRequest, opts ...grpc.CallOption) (*RemoveBuildArtifactResponse, error) {
+	out := new(RemoveBuildArtifactResponse)
+	err := c.cc.Invoke(ctx, "/build_collector.v1alpha1.BuildCollector/RemoveBuildArtifact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildCollectorClient) ReplaceBuildArtifacts(ctx context.Context, in *ReplaceBuildArtifactsRequest, opts ...grpc.CallOption) (*ReplaceBuildArtifactsResponse, error) {
+	out := new(ReplaceBuildArtifactsResponse)
+	err := c.cc.Invoke(ctx, "/build_collector.v1alpha1.BuildCollector/ReplaceBuildArtifacts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildCollectorClient) GetBuild(ctx context.Context, in *GetBuildRequest, opts ...grpc.CallOption) (*Build, error) {
+	out := new(Build)
+	err := c.cc.Invoke(ctx, "/build_collector.v1alpha1.BuildCollector/GetBuild", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildCollectorClient) ListBuilds(ctx context.Context, in *ListBuildsRequest, opts ...grpc.CallOption) (*ListBuildsResponse, error) {
+	out := new(ListBuildsResponse)
+	err := c.cc.Invoke(ctx, "/build_collector.v1alpha1.BuildCollector/ListBuilds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildCollectorClient) GetBuildProvenance(ctx context.Context, in *GetBuildProvenanceRequest, opts ...grpc.CallOption) (*GetBuildProvenanceResponse, error) {
+	out := new(GetBuildProvenanceResponse)
+	err := c.cc.Invoke(ctx, "/build_collector.v1alpha1.BuildCollector/GetBuildProvenance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildCollectorClient) IngestProvenance(ctx context.Context, in *IngestProvenanceRequest, opts ...grpc.CallOption) (*CreateBuildResponse, error) {
+	out := new(CreateBuildResponse)
+	err := c.cc.Invoke(ctx, "/build_collector.v1alpha1.BuildCollector/IngestProvenance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildCollectorClient) VerifyBuild(ctx context.Context, in *VerifyBuildRequest, opts ...grpc.CallOption) (*VerifyBuildResponse, error) {
+	out := new(VerifyBuildResponse)
+	err := c.cc.Invoke(ctx, "/build_collector.v1alpha1.BuildCollector/VerifyBuild", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildCollectorClient) GetSigningKey(ctx context.Context, in *GetSigningKeyRequest, opts ...grpc.CallOption) (*GetSigningKeyResponse, error) {
+	out := new(GetSigningKeyResponse)
+	err := c.cc.Invoke(ctx, "/build_collector.v1alpha1.BuildCollector/GetSigningKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildCollectorClient) AttachSbom(ctx context.Context, in *AttachSbomRequest, opts ...grpc.CallOption) (*AttachSbomResponse, error) {
+	out := new(AttachSbomResponse)
+	err := c.cc.Invoke(ctx, "/build_collector.v1alpha1.BuildCollector/AttachSbom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *buildCollectorClient) UploadSbom(ctx context.Context, opts ...grpc.CallOption) (BuildCollector_UploadSbomClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BuildCollector_ServiceDesc.Streams[0], "/build_collector.v1alpha1.BuildCollector/UploadSbom", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &buildCollectorUploadSbomClient{stream}
+	return x, nil
+}
+
+type BuildCollector_UploadSbomClient interface {
+	Send(*UploadSbomRequest) error
+	CloseAndRecv() (*AttachSbomResponse, error)
+	grpc.ClientStream
+}
+
+type buildCollectorUploadSbomClient struct {
+	grpc.ClientStream
+}
+
+func (x *buildCollectorUploadSbomClient) Send(m *UploadSbomRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *buildCollectorUploadSbomClient) CloseAndRecv() (*AttachSbomResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(AttachSbomResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // BuildCollectorServer is the server API for BuildCollector service.
 // All implementations should embed UnimplementedBuildCollectorServer
 // for forward compatibility
 type BuildCollectorServer interface {
 	CreateBuild(context.Context, *CreateBuildRequest) (*CreateBuildResponse, error)
+	BatchCreateBuilds(context.Context, *BatchCreateBuildsRequest) (*BatchCreateBuildsResponse, error)
 	UpdateBuildArtifacts(context.Context, *UpdateBuildArtifactsRequest) (*UpdateBuildArtifactsResponse, error)
+	RemoveBuildArtifact(context.Context, *RemoveBuildArtifactRequest) (*RemoveBuildArtifactResponse, error)
+	ReplaceBuildArtifacts(context.Context, *ReplaceBuildArtifactsRequest) (*ReplaceBuildArtifactsResponse, error)
+	GetBuild(context.Context, *GetBuildRequest) (*Build, error)
+	ListBuilds(context.Context, *ListBuildsRequest) (*ListBuildsResponse, error)
+	GetBuildProvenance(context.Context, *GetBuildProvenanceRequest) (*GetBuildProvenanceResponse, error)
+	IngestProvenance(context.Context, *IngestProvenanceRequest) (*CreateBuildResponse, error)
+	VerifyBuild(context.Context, *VerifyBuildRequest) (*VerifyBuildResponse, error)
+	// GetSigningKey returns the public key that build occurrences are signed with, so that they can be verified
+	// outside of the collector
+	GetSigningKey(context.Context, *GetSigningKeyRequest) (*GetSigningKeyResponse, error)
+	AttachSbom(context.Context, *AttachSbomRequest) (*AttachSbomResponse, error)
+	// UploadSbom attaches an SBOM that's too large to send in a single message. The first message on the stream sets
+	// the build, artifact and format, and the rest carry the document in chunks.
+	UploadSbom(BuildCollector_UploadSbomServer) error
 }
 
 // UnimplementedBuildCollectorServer should be embedded to have forward compatible implementations.
@@ -63,9 +217,42 @@ type UnimplementedBuildCollectorServer struct {
 func (UnimplementedBuildCollectorServer) CreateBuild(context.Context, *CreateBuildRequest) (*CreateBuildResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateBuild not implemented")
 }
+func (UnimplementedBuildCollectorServer) BatchCreateBuilds(context.Context, *BatchCreateBuildsRequest) (*BatchCreateBuildsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateBuilds not implemented")
+}
 func (UnimplementedBuildCollectorServer) UpdateBuildArtifacts(context.Context, *UpdateBuildArtifactsRequest) (*UpdateBuildArtifactsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateBuildArtifacts not implemented")
 }
+func (UnimplementedBuildCollectorServer) RemoveBuildArtifact(context.Context, *RemoveBuildArtifactRequest) (*RemoveBuildArtifactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveBuildArtifact not implemented")
+}
+func (UnimplementedBuildCollectorServer) ReplaceBuildArtifacts(context.Context, *ReplaceBuildArtifactsRequest) (*ReplaceBuildArtifactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplaceBuildArtifacts not implemented")
+}
+func (UnimplementedBuildCollectorServer) GetBuild(context.Context, *GetBuildRequest) (*Build, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBuild not implemented")
+}
+func (UnimplementedBuildCollectorServer) ListBuilds(context.Context, *ListBuildsRequest) (*ListBuildsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBuilds not implemented")
+}
+func (UnimplementedBuildCollectorServer) GetBuildProvenance(context.Context, *GetBuildProvenanceRequest) (*GetBuildProvenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBuildProvenance not implemented")
+}
+func (UnimplementedBuildCollectorServer) IngestProvenance(context.Context, *IngestProvenanceRequest) (*CreateBuildResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IngestProvenance not implemented")
+}
+func (UnimplementedBuildCollectorServer) VerifyBuild(context.Context, *VerifyBuildRequest) (*VerifyBuildResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyBuild not implemented")
+}
+func (UnimplementedBuildCollectorServer) GetSigningKey(context.Context, *GetSigningKeyRequest) (*GetSigningKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSigningKey not implemented")
+}
+func (UnimplementedBuildCollectorServer) AttachSbom(context.Context, *AttachSbomRequest) (*AttachSbomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AttachSbom not implemented")
+}
+func (UnimplementedBuildCollectorServer) UploadSbom(BuildCollector_UploadSbomServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadSbom not implemented")
+}
 
 // UnsafeBuildCollectorServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to BuildCollectorServer will
@@ -96,6 +283,24 @@ func _BuildCollector_CreateBuild_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BuildCollector_BatchCreateBuilds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateBuildsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildCollectorServer).BatchCreateBuilds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/build_collector.v1alpha1.BuildCollector/BatchCreateBuilds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildCollectorServer).BatchCreateBuilds(ctx, req.(*BatchCreateBuildsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BuildCollector_UpdateBuildArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateBuildArtifactsRequest)
 	if err := dec(in); err != nil {
@@ -114,6 +319,194 @@ func _BuildCollector_UpdateBuildArtifacts_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BuildCollector_RemoveBuildArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveBuildArtifactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildCollectorServer).RemoveBuildArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/build_collector.v1alpha1.BuildCollector/RemoveBuildArtifact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildCollectorServer).RemoveBuildArtifact(ctx, req.(*RemoveBuildArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuildCollector_ReplaceBuildArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplaceBuildArtifactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildCollectorServer).ReplaceBuildArtifacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/build_collector.v1alpha1.BuildCollector/ReplaceBuildArtifacts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildCollectorServer).ReplaceBuildArtifacts(ctx, req.(*ReplaceBuildArtifactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuildCollector_GetBuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildCollectorServer).GetBuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/build_collector.v1alpha1.BuildCollector/GetBuild",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildCollectorServer).GetBuild(ctx, req.(*GetBuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuildCollector_ListBuilds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBuildsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildCollectorServer).ListBuilds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/build_collector.v1alpha1.BuildCollector/ListBuilds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildCollectorServer).ListBuilds(ctx, req.(*ListBuildsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuildCollector_GetBuildProvenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBuildProvenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildCollectorServer).GetBuildProvenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/build_collector.v1alpha1.BuildCollector/GetBuildProvenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildCollectorServer).GetBuildProvenance(ctx, req.(*GetBuildProvenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuildCollector_IngestProvenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestProvenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildCollectorServer).IngestProvenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/build_collector.v1alpha1.BuildCollector/IngestProvenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildCollectorServer).IngestProvenance(ctx, req.(*IngestProvenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuildCollector_VerifyBuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyBuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildCollectorServer).VerifyBuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/build_collector.v1alpha1.BuildCollector/VerifyBuild",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildCollectorServer).VerifyBuild(ctx, req.(*VerifyBuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuildCollector_GetSigningKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSigningKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildCollectorServer).GetSigningKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/build_collector.v1alpha1.BuildCollector/GetSigningKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildCollectorServer).GetSigningKey(ctx, req.(*GetSigningKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuildCollector_AttachSbom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachSbomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildCollectorServer).AttachSbom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/build_collector.v1alpha1.BuildCollector/AttachSbom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildCollectorServer).AttachSbom(ctx, req.(*AttachSbomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BuildCollector_UploadSbom_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BuildCollectorServer).UploadSbom(&buildCollectorUploadSbomServer{stream})
+}
+
+type BuildCollector_UploadSbomServer interface {
+	SendAndClose(*AttachSbomResponse) error
+	Recv() (*UploadSbomRequest, error)
+	grpc.ServerStream
+}
+
+type buildCollectorUploadSbomServer struct {
+	grpc.ServerStream
+}
+
+func (x *buildCollectorUploadSbomServer) SendAndClose(m *AttachSbomResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *buildCollectorUploadSbomServer) Recv() (*UploadSbomRequest, error) {
+	m := new(UploadSbomRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // BuildCollector_ServiceDesc is the grpc.ServiceDesc for BuildCollector service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -125,11 +518,57 @@ var BuildCollector_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateBuild",
 			Handler:    _BuildCollector_CreateBuild_Handler,
 		},
+		{
+			MethodName: "BatchCreateBuilds",
+			Handler:    _BuildCollector_BatchCreateBuilds_Handler,
+		},
 		{
 			MethodName: "UpdateBuildArtifacts",
 			Handler:    _BuildCollector_UpdateBuildArtifacts_Handler,
 		},
+		{
+			MethodName: "RemoveBuildArtifact",
+			Handler:    _BuildCollector_RemoveBuildArtifact_Handler,
+		},
+		{
+			MethodName: "ReplaceBuildArtifacts",
+			Handler:    _BuildCollector_ReplaceBuildArtifacts_Handler,
+		},
+		{
+			MethodName: "GetBuild",
+			Handler:    _BuildCollector_GetBuild_Handler,
+		},
+		{
+			MethodName: "ListBuilds",
+			Handler:    _BuildCollector_ListBuilds_Handler,
+		},
+		{
+			MethodName: "GetBuildProvenance",
+			Handler:    _BuildCollector_GetBuildProvenance_Handler,
+		},
+		{
+			MethodName: "IngestProvenance",
+			Handler:    _BuildCollector_IngestProvenance_Handler,
+		},
+		{
+			MethodName: "VerifyBuild",
+			Handler:    _BuildCollector_VerifyBuild_Handler,
+		},
+		{
+			MethodName: "GetSigningKey",
+			Handler:    _BuildCollector_GetSigningKey_Handler,
+		},
+		{
+			MethodName: "AttachSbom",
+			Handler:    _BuildCollector_AttachSbom_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadSbom",
+			Handler:       _BuildCollector_UploadSbom_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/v1alpha1/build_collector.proto",
 }
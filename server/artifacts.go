@@ -0,0 +1,302 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rode/collector-build/proto/v1alpha1"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/provenance_go_proto"
+)
+
+// artifactDigestHexLengths are the supported artifact digest algorithms and the length of their hex encoded values
+var artifactDigestHexLengths = map[string]int{
+	"sha1":   40,
+	"sha256": 64,
+	"sha512": 128,
+}
+
+// digestLikePattern matches values in the form of an "algorithm:hex" digest
+var digestLikePattern = regexp.MustCompile(`^([A-Za-z0-9]+):([A-Fa-f0-9]+)$`)
+
+// knownDigestAlgorithms are recognized as digest algorithms, whether or not they're supported for artifacts
+var knownDigestAlgorithms = map[string]bool{
+	"md5":    true,
+	"sha1":   true,
+	"sha224": true,
+	"sha256": true,
+	"sha384": true,
+	"sha512": true,
+}
+
+// normalizeArtifact returns a copy of the artifact with a canonical id. When the artifact has a structured digest,
+// it's appended to the id, and must match any digest the id already contains.
+func normalizeArtifact(artifact *v1alpha1.Artifact) (*v1alpha1.Artifact, error) {
+	if isPackageURL(strings.TrimSpace(artifact.Id)) {
+		return normalizePackageArtifact(artifact)
+	}
+
+	id, err := normalizeArtifactId(artifact.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if artifact.Digest != nil {
+		digest, err := normalizeDigest(artifact.Digest.Algorithm, artifact.Digest.Hex)
+		if err != nil {
+			return nil, fmt.Errorf("artifact %s has an invalid digest: %s", artifact.Id, err)
+		}
+
+		reference, idDigest := splitArtifactId(id)
+		switch {
+		case idDigest != "" && idDigest != digest:
+			return nil, fmt.Errorf("artifact id %s does not match digest %s", artifact.Id, digest)
+		case idDigest == "" && reference == "":
+			id = digest
+		case idDigest == "":
+			id = removeImageTag(reference) + "@" + digest
+		}
+	}
+
+	if id == "" {
+		return nil, errors.New("artifact id must be specified")
+	}
+
+	return &v1alpha1.Artifact{
+		Id:     id,
+		Names:  artifact.Names,
+		Digest: parseArtifactDigest(id),
+	}, nil
+}
+
+// normalizePackageArtifact identifies an artifact with a package url id by its Rode resource uri. The canonical
+// package url is kept in the artifact names, and a structured digest is recorded as a checksum qualifier on it.
+// When the resource uri doesn't include the digest, as with Maven and npm packages, the checksum is kept as the
+// artifact's digest.
+func normalizePackageArtifact(artifact *v1alpha1.Artifact) (*v1alpha1.Artifact, error) {
+	purl, err := parsePackageURL(strings.TrimSpace(artifact.Id))
+	if err != nil {
+		return nil, fmt.Errorf("invalid artifact id %s: %s", artifact.Id, err)
+	}
+
+	if artifact.Digest != nil {
+		digest, err := normalizeDigest(artifact.Digest.Algorithm, artifact.Digest.Hex)
+		if err != nil {
+			return nil, fmt.Errorf("artifact %s has an invalid digest: %s", artifact.Id, err)
+		}
+
+		if err := purl.addChecksum(digest); err != nil {
+			return nil, fmt.Errorf("artifact id %s does not match digest %s: %s", artifact.Id, digest, err)
+		}
+	}
+
+	id, err := packageURLToResourceUri(purl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid artifact id %s: %s", artifact.Id, err)
+	}
+
+	names := artifact.Names
+	if canonical := purl.String(); canonical != id && !containsString(names, canonical) {
+		names = append(append([]string{}, names...), canonical)
+	}
+
+	digest := parseArtifactDigest(id)
+	if digest == nil {
+		digest = purl.checksumDigest()
+	}
+
+	return &v1alpha1.Artifact{
+		Id:     id,
+		Names:  names,
+		Digest: digest,
+	}, nil
+}
+
+// normalizeArtifactId converts the ways that artifacts are commonly identified by a digest into one form:
+// "algorithm:hex" on its own, or "reference@algorithm:hex" with any tag removed from the reference.
+// Bare hex values are assumed to be a digest of the algorithm with a matching length, and package urls
+// are converted to Rode resource uris. Other ids are returned unchanged.
+func normalizeArtifactId(id string) (string, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return "", nil
+	}
+
+	if isPackageURL(id) {
+		purl, err := parsePackageURL(id)
+		if err != nil {
+			return "", fmt.Errorf("invalid artifact id %s: %s", id, err)
+		}
+
+		resourceUri, err := packageURLToResourceUri(purl)
+		if err != nil {
+			return "", fmt.Errorf("invalid artifact id %s: %s", id, err)
+		}
+
+		return resourceUri, nil
+	}
+
+	if i := strings.LastIndex(id, "@"); i != -1 && isDigestLike(id[i+1:]) {
+		digest, err := normalizeDigestString(id[i+1:])
+		if err != nil {
+			return "", fmt.Errorf("invalid artifact id %s: %s", id, err)
+		}
+
+		return removeImageTag(id[:i]) + "@" + digest, nil
+	}
+
+	if isDigestLike(id) {
+		digest, err := normalizeDigestString(id)
+		if err != nil {
+			return "", fmt.Errorf("invalid artifact id %s: %s", id, err)
+		}
+
+		return digest, nil
+	}
+
+	if _, err := hex.DecodeString(id); err == nil {
+		for algorithm, length := range artifactDigestHexLengths {
+			if len(id) == length {
+				return algorithm + ":" + strings.ToLower(id), nil
+			}
+		}
+	}
+
+	return id, nil
+}
+
+// isDigestLike reports whether the value is an attempt at an "algorithm:hex" digest, so that malformed digests are
+// rejected instead of being stored as free-form ids. The algorithm must be a known one; ids like "myapp:1234" or an
+// image tagged with a git commit are image tags rather than digests.
+func isDigestLike(value string) bool {
+	matches := digestLikePattern.FindStringSubmatch(value)
+
+	return matches != nil && knownDigestAlgorithms[strings.ToLower(matches[1])]
+}
+
+// artifactLookupIds returns the ids to search for when looking up an existing artifact. Artifacts recorded before ids
+// were normalized may have been stored with the id as it was given, so that's searched for alongside the normalized id.
+func artifactLookupIds(id string) ([]string, error) {
+	normalized, err := normalizeArtifactId(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if raw := strings.TrimSpace(id); raw != normalized {
+		return []string{normalized, raw}, nil
+	}
+
+	return []string{normalized}, nil
+}
+
+func normalizeDigestString(digest string) (string, error) {
+	pieces := strings.SplitN(digest, ":", 2)
+	if len(pieces) != 2 {
+		return "", fmt.Errorf("digest %q must be in the form algorithm:hex", digest)
+	}
+
+	return normalizeDigest(pieces[0], pieces[1])
+}
+
+func normalizeDigest(algorithm, value string) (string, error) {
+	algorithm = strings.ToLower(algorithm)
+	length, ok := artifactDigestHexLengths[algorithm]
+	if !ok {
+		return "", fmt.Errorf("unsupported digest algorithm %q", algorithm)
+	}
+
+	if _, err := hex.DecodeString(value); err != nil || len(value) != length {
+		return "", fmt.Errorf("%s digest must be %d hex characters", algorithm, length)
+	}
+
+	return algorithm + ":" + strings.ToLower(value), nil
+}
+
+// splitArtifactId separates a normalized artifact id into its reference and digest, either of which may be empty
+func splitArtifactId(id string) (string, string) {
+	if i := strings.LastIndex(id, "@"); i != -1 {
+		if _, err := normalizeDigestString(id[i+1:]); err == nil {
+			return id[:i], id[i+1:]
+		}
+	}
+
+	if _, err := normalizeDigestString(id); err == nil {
+		return "", id
+	}
+
+	return id, ""
+}
+
+// parseArtifactDigest returns the digest of a normalized artifact id, or nil if it doesn't have one
+func parseArtifactDigest(id string) *v1alpha1.Digest {
+	_, digest := splitArtifactId(id)
+	if digest == "" {
+		return nil
+	}
+
+	pieces := strings.SplitN(digest, ":", 2)
+
+	return &v1alpha1.Digest{
+		Algorithm: pieces[0],
+		Hex:       pieces[1],
+	}
+}
+
+// artifactChecksum returns the digest of a normalized artifact whose id doesn't include it, like a Maven or npm package,
+// so that it can be recorded as the checksum of the built artifact. It's empty when the id has the digest or there's none.
+func artifactChecksum(artifact *v1alpha1.Artifact) string {
+	if artifact.Digest == nil || parseArtifactDigest(artifact.Id) != nil {
+		return ""
+	}
+
+	return artifact.Digest.Algorithm + ":" + artifact.Digest.Hex
+}
+
+// builtArtifactDigest returns the digest of a built artifact, from its id or else its checksum
+func builtArtifactDigest(artifact *provenance_go_proto.Artifact) *v1alpha1.Digest {
+	if digest := parseArtifactDigest(artifact.Id); digest != nil {
+		return digest
+	}
+
+	return parseArtifactDigest(artifact.Checksum)
+}
+
+// removeImageTag drops the tag from an image reference, leaving any registry port in place. URLs are left unchanged.
+func removeImageTag(reference string) string {
+	if strings.Contains(reference, "://") {
+		return reference
+	}
+
+	name := reference[strings.LastIndex(reference, "/")+1:]
+	if i := strings.LastIndex(name, ":"); i != -1 {
+		return reference[:len(reference)-len(name)+i]
+	}
+
+	return reference
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,139 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/rode/collector-build/proto/v1alpha1"
+)
+
+var _ = Describe("artifacts", func() {
+	var (
+		sha1Hex   = strings.Repeat("a1", 20)
+		sha256Hex = strings.Repeat("b2", 32)
+		sha512Hex = strings.Repeat("c3", 64)
+	)
+
+	Describe("normalizeArtifactId", func() {
+		DescribeTable("valid ids", func(id, expected string) {
+			actual, err := normalizeArtifactId(id)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual).To(Equal(expected))
+		},
+			Entry("empty", "", ""),
+			Entry("free-form name", "collector-build", "collector-build"),
+			Entry("url", "https://example.com/releases/collector-build.tgz", "https://example.com/releases/collector-build.tgz"),
+			Entry("tagged image without a digest", "harbor.example.com/rode/collector-build:v0.1.0", "harbor.example.com/rode/collector-build:v0.1.0"),
+			Entry("digest", "sha256:"+sha256Hex, "sha256:"+sha256Hex),
+			Entry("uppercase digest", "SHA512:"+strings.ToUpper(sha512Hex), "sha512:"+sha512Hex),
+			Entry("bare sha1", sha1Hex, "sha1:"+sha1Hex),
+			Entry("bare sha256", strings.ToUpper(sha256Hex), "sha256:"+sha256Hex),
+			Entry("bare sha512", sha512Hex, "sha512:"+sha512Hex),
+			Entry("image with digest", "harbor.example.com/rode/collector-build@sha256:"+sha256Hex, "harbor.example.com/rode/collector-build@sha256:"+sha256Hex),
+			Entry("image with tag and digest", "harbor.example.com:5000/rode/collector-build:v0.1.0@sha256:"+sha256Hex, "harbor.example.com:5000/rode/collector-build@sha256:"+sha256Hex),
+			Entry("library image with tag and digest", "alpine:3.14@sha256:"+sha256Hex, "alpine@sha256:"+sha256Hex),
+			Entry("surrounding whitespace", " sha256:"+sha256Hex+" ", "sha256:"+sha256Hex),
+			Entry("image tagged with a number", "myapp:1234", "myapp:1234"),
+			Entry("image tagged with a date", "app:20211005", "app:20211005"),
+			Entry("image tagged with a git commit", "myapp:"+sha1Hex, "myapp:"+sha1Hex),
+			Entry("image tagged with a digest length of hex", "myapp:"+sha256Hex, "myapp:"+sha256Hex),
+			Entry("image tagged with a number and a digest", "myapp:1234@sha256:"+sha256Hex, "myapp@sha256:"+sha256Hex),
+		)
+
+		DescribeTable("invalid ids", func(id string) {
+			_, err := normalizeArtifactId(id)
+
+			Expect(err).To(HaveOccurred())
+		},
+			Entry("unsupported algorithm", "md5:"+strings.Repeat("ab", 16)),
+			Entry("short sha256", "sha256:abc123"),
+			Entry("sha1 length for sha256", "sha256:"+sha1Hex),
+			Entry("image with a short digest", "harbor.example.com/rode/collector-build@sha256:abc"),
+			Entry("image with an unsupported algorithm", "harbor.example.com/rode/collector-build@md5:"+strings.Repeat("ab", 16)),
+		)
+	})
+
+	Describe("artifactLookupIds", func() {
+		It("should include the given id when it differs from the normalized id", func() {
+			Expect(artifactLookupIds("alpine:3.14@sha256:" + strings.ToUpper(sha256Hex))).To(Equal([]string{
+				"alpine@sha256:" + sha256Hex,
+				"alpine:3.14@sha256:" + strings.ToUpper(sha256Hex),
+			}))
+		})
+
+		It("should only include the normalized id when it's unchanged", func() {
+			Expect(artifactLookupIds(" sha256:" + sha256Hex)).To(Equal([]string{"sha256:" + sha256Hex}))
+		})
+	})
+
+	Describe("normalizeArtifact", func() {
+		DescribeTable("structured digests", func(artifact *v1alpha1.Artifact, expectedId string) {
+			actual, err := normalizeArtifact(artifact)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Id).To(Equal(expectedId))
+			Expect(actual.Names).To(Equal(artifact.Names))
+		},
+			Entry("digest only", &v1alpha1.Artifact{
+				Digest: &v1alpha1.Digest{Algorithm: "sha256", Hex: sha256Hex},
+			}, "sha256:"+sha256Hex),
+			Entry("tagged image", &v1alpha1.Artifact{
+				Id:     "harbor.example.com/rode/collector-build:latest",
+				Names:  []string{"harbor.example.com/rode/collector-build:latest"},
+				Digest: &v1alpha1.Digest{Algorithm: "sha256", Hex: sha256Hex},
+			}, "harbor.example.com/rode/collector-build@sha256:"+sha256Hex),
+			Entry("id with the same digest", &v1alpha1.Artifact{
+				Id:     "harbor.example.com/rode/collector-build@sha256:" + strings.ToUpper(sha256Hex),
+				Digest: &v1alpha1.Digest{Algorithm: "SHA256", Hex: sha256Hex},
+			}, "harbor.example.com/rode/collector-build@sha256:"+sha256Hex),
+		)
+
+		DescribeTable("invalid artifacts", func(artifact *v1alpha1.Artifact) {
+			_, err := normalizeArtifact(artifact)
+
+			Expect(err).To(HaveOccurred())
+		},
+			Entry("no id or digest", &v1alpha1.Artifact{}),
+			Entry("unsupported algorithm", &v1alpha1.Artifact{
+				Digest: &v1alpha1.Digest{Algorithm: "md5", Hex: strings.Repeat("ab", 16)},
+			}),
+			Entry("wrong length", &v1alpha1.Artifact{
+				Digest: &v1alpha1.Digest{Algorithm: "sha512", Hex: sha256Hex},
+			}),
+			Entry("conflicting digest", &v1alpha1.Artifact{
+				Id:     "harbor.example.com/rode/collector-build@sha256:" + sha256Hex,
+				Digest: &v1alpha1.Digest{Algorithm: "sha256", Hex: strings.Repeat("dd", 32)},
+			}),
+		)
+	})
+
+	Describe("parseArtifactDigest", func() {
+		It("should return the digest of an image reference", func() {
+			Expect(parseArtifactDigest("harbor.example.com/rode/collector-build@sha256:" + sha256Hex)).To(Equal(&v1alpha1.Digest{
+				Algorithm: "sha256",
+				Hex:       sha256Hex,
+			}))
+		})
+
+		It("should return nil when the id has no digest", func() {
+			Expect(parseArtifactDigest("collector-build")).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,112 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/rode/collector-build/config"
+)
+
+// provenanceKeyIdBuildOption records the id of the trusted key that verified the signature on ingested provenance
+const provenanceKeyIdBuildOption = "provenance_key_id"
+
+// dsseEnvelope is a Dead Simple Signing Envelope, as produced by in-toto attestation tooling
+type dsseEnvelope struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     string          `json:"payload"`
+	Signatures  []dsseSignature `json:"signatures"`
+}
+
+type dsseSignature struct {
+	KeyId string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// dssePreAuthEncoding returns the bytes that DSSE signatures are computed over
+func dssePreAuthEncoding(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// verifyDsseEnvelope checks the envelope's signatures against the trusted keys and returns the id of the first key
+// that verifies one of them. A signature's keyid is used to pick the key when it names a trusted key, otherwise
+// every trusted key is tried.
+func verifyDsseEnvelope(envelope *dsseEnvelope, payload []byte, keys []*config.TrustedKey) (string, error) {
+	if len(envelope.Signatures) == 0 {
+		return "", errors.New("envelope has no signatures")
+	}
+
+	message := dssePreAuthEncoding(envelope.PayloadType, payload)
+	for _, signature := range envelope.Signatures {
+		sig, err := decodeDsseSignature(signature.Sig)
+		if err != nil {
+			continue
+		}
+
+		for _, key := range dsseSignatureKeys(signature, keys) {
+			if verifySignature(key.PublicKey, message, sig) {
+				return key.Id, nil
+			}
+		}
+	}
+
+	return "", errors.New("no signature could be verified with a trusted key")
+}
+
+func dsseSignatureKeys(signature dsseSignature, keys []*config.TrustedKey) []*config.TrustedKey {
+	for _, key := range keys {
+		if signature.KeyId != "" && key.Id == signature.KeyId {
+			return []*config.TrustedKey{key}
+		}
+	}
+
+	return keys
+}
+
+// decodeDsseSignature accepts both standard and URL-safe base64, since signing tools differ in which they produce
+func decodeDsseSignature(sig string) ([]byte, error) {
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+		if decoded, err := encoding.DecodeString(sig); err == nil {
+			return decoded, nil
+		}
+	}
+
+	return nil, errors.New("signature is not valid base64")
+}
+
+// verifySignature checks a signature over message: ECDSA and RSA signatures are over its SHA-256 digest,
+// RSA signatures may use either PKCS #1 v1.5 or PSS padding
+func verifySignature(publicKey crypto.PublicKey, message, sig []byte) bool {
+	digest := sha256.Sum256(message)
+
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest[:], sig)
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, message, sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil ||
+			rsa.VerifyPSS(key, crypto.SHA256, digest[:], sig, nil) == nil
+	}
+
+	return false
+}
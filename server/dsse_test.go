@@ -0,0 +1,158 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/rode/collector-build/config"
+)
+
+// newSignedEnvelope wraps the statement in a DSSE envelope with a single signature made by sign
+func newSignedEnvelope(statement, keyId string, sign func(message []byte) []byte) string {
+	sig := sign(dssePreAuthEncoding(inTotoPayloadType, []byte(statement)))
+	envelope, err := json.Marshal(&dsseEnvelope{
+		PayloadType: inTotoPayloadType,
+		Payload:     base64.StdEncoding.EncodeToString([]byte(statement)),
+		Signatures:  []dsseSignature{{KeyId: keyId, Sig: base64.StdEncoding.EncodeToString(sig)}},
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	return string(envelope)
+}
+
+func ed25519Signer(privateKey ed25519.PrivateKey) func([]byte) []byte {
+	return func(message []byte) []byte {
+		return ed25519.Sign(privateKey, message)
+	}
+}
+
+var _ = Describe("dsse", func() {
+	It("should compute the pre-authentication encoding", func() {
+		Expect(string(dssePreAuthEncoding("http://example.com/HelloWorld", []byte("hello world")))).
+			To(Equal("DSSEv1 29 http://example.com/HelloWorld 11 hello world"))
+	})
+
+	Describe("verifyDsseEnvelope", func() {
+		var (
+			payload    []byte
+			publicKey  ed25519.PublicKey
+			privateKey ed25519.PrivateKey
+			keys       []*config.TrustedKey
+		)
+
+		BeforeEach(func() {
+			payload = []byte(slsaV02Statement)
+			publicKey, privateKey, _ = ed25519.GenerateKey(rand.Reader)
+			otherKey, _, _ := ed25519.GenerateKey(rand.Reader)
+			keys = []*config.TrustedKey{
+				{Id: "other", PublicKey: otherKey},
+				{Id: "release", PublicKey: publicKey},
+			}
+		})
+
+		envelopeFor := func(keyId string) *dsseEnvelope {
+			envelope := &dsseEnvelope{}
+			Expect(json.Unmarshal([]byte(newSignedEnvelope(string(payload), keyId, ed25519Signer(privateKey))), envelope)).To(Succeed())
+
+			return envelope
+		}
+
+		It("should return the id of the key named by the signature", func() {
+			Expect(verifyDsseEnvelope(envelopeFor("release"), payload, keys)).To(Equal("release"))
+		})
+
+		It("should try every key when the signature doesn't name a trusted key", func() {
+			Expect(verifyDsseEnvelope(envelopeFor("ci-key"), payload, keys)).To(Equal("release"))
+		})
+
+		It("should accept URL-safe base64 signatures", func() {
+			envelope := envelopeFor("")
+			sig, _ := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+			envelope.Signatures[0].Sig = base64.RawURLEncoding.EncodeToString(sig)
+
+			Expect(verifyDsseEnvelope(envelope, payload, keys)).To(Equal("release"))
+		})
+
+		It("should reject a signature over a different payload", func() {
+			envelope := envelopeFor("release")
+
+			_, err := verifyDsseEnvelope(envelope, []byte(slsaV1Statement), keys)
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a signature from an untrusted key", func() {
+			_, err := verifyDsseEnvelope(envelopeFor("release"), payload, keys[:1])
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject an envelope without signatures", func() {
+			_, err := verifyDsseEnvelope(&dsseEnvelope{PayloadType: inTotoPayloadType}, payload, keys)
+
+			Expect(err).To(MatchError("envelope has no signatures"))
+		})
+	})
+
+	DescribeTable("verifySignature", func(generate func() (crypto.PublicKey, func([]byte) []byte)) {
+		publicKey, sign := generate()
+		message := []byte("DSSEv1 28 application/vnd.in-toto+json 2 {}")
+		sig := sign(message)
+
+		Expect(verifySignature(publicKey, message, sig)).To(BeTrue())
+		Expect(verifySignature(publicKey, []byte("tampered"), sig)).To(BeFalse())
+	},
+		Entry("ECDSA P-256", func() (crypto.PublicKey, func([]byte) []byte) {
+			key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			return &key.PublicKey, func(message []byte) []byte {
+				digest := sha256.Sum256(message)
+				sig, _ := ecdsa.SignASN1(rand.Reader, key, digest[:])
+				return sig
+			}
+		}),
+		Entry("Ed25519", func() (crypto.PublicKey, func([]byte) []byte) {
+			publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+			return publicKey, ed25519Signer(privateKey)
+		}),
+		Entry("RSA PKCS #1 v1.5", func() (crypto.PublicKey, func([]byte) []byte) {
+			key, _ := rsa.GenerateKey(rand.Reader, 2048)
+			return &key.PublicKey, func(message []byte) []byte {
+				digest := sha256.Sum256(message)
+				sig, _ := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+				return sig
+			}
+		}),
+		Entry("RSA PSS", func() (crypto.PublicKey, func([]byte) []byte) {
+			key, _ := rsa.GenerateKey(rand.Reader, 2048)
+			return &key.PublicKey, func(message []byte) []byte {
+				digest := sha256.Sum256(message)
+				sig, _ := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], nil)
+				return sig
+			}
+		}),
+	)
+})
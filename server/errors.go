@@ -0,0 +1,165 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+// errorDomain identifies the collector as the source of ErrorInfo details
+const errorDomain = "build-collector.rode.io"
+
+// stable reasons for failed calls to Rode, clients can rely on these not changing
+const (
+	reasonRodeCreateOccurrencesFailed = "RODE_CREATE_OCCURRENCES_FAILED"
+	reasonRodeListOccurrencesFailed   = "RODE_LIST_OCCURRENCES_FAILED"
+	reasonRodeUpdateOccurrenceFailed  = "RODE_UPDATE_OCCURRENCE_FAILED"
+	reasonRodeMissingOccurrenceData   = "RODE_MISSING_OCCURRENCE_DATA"
+)
+
+// resource types reported in ResourceInfo details
+const (
+	resourceTypeArtifact        = "artifact"
+	resourceTypeBuildOccurrence = "build occurrence"
+	resourceTypeBuildProvenance = "build provenance"
+	resourceTypeSbomOccurrence  = "SBOM occurrence"
+)
+
+// fieldError is a validation error for a single field in a request, the field is a path like "artifacts[0].id"
+type fieldError struct {
+	field       string
+	description string
+}
+
+func newFieldError(field, format string, args ...interface{}) error {
+	return &fieldError{
+		field:       field,
+		description: fmt.Sprintf(format, args...),
+	}
+}
+
+// fieldErrorFrom attributes err to a field, unless it's already a fieldError with a more specific path
+func fieldErrorFrom(field string, err error) error {
+	var fieldErr *fieldError
+	if errors.As(err, &fieldErr) {
+		return err
+	}
+
+	return &fieldError{
+		field:       field,
+		description: err.Error(),
+	}
+}
+
+func (e *fieldError) Error() string {
+	return e.description
+}
+
+// invalidRequestError builds an InvalidArgument error for a request that failed validation.
+// A BadRequest detail is attached when the error can be traced back to a field.
+func invalidRequestError(err error) error {
+	return invalidArgumentError("Invalid request", err)
+}
+
+func invalidArgumentError(message string, err error) error {
+	s := status.Newf(codes.InvalidArgument, "%s: %s", message, err)
+
+	var fieldErr *fieldError
+	if !errors.As(err, &fieldErr) {
+		return s.Err()
+	}
+
+	return withDetails(s, &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{
+				Field:       fieldErr.field,
+				Description: fieldErr.description,
+			},
+		},
+	}).Err()
+}
+
+// notFoundError builds a NotFound error with a ResourceInfo detail describing the missing resource
+func notFoundError(resourceType, resourceName, format string, args ...interface{}) error {
+	description := fmt.Sprintf(format, args...)
+
+	return withDetails(status.New(codes.NotFound, description), &errdetails.ResourceInfo{
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		Description:  description,
+	}).Err()
+}
+
+// signedProvenanceRequiredError rejects requests that record a build without provenance signed by a trusted key
+func signedProvenanceRequiredError() error {
+	return status.Error(codes.FailedPrecondition, "Signed provenance is required, builds must be recorded with IngestProvenance")
+}
+
+// signedArtifactsRequiredError rejects requests that change the artifacts of a build, which would no longer match the
+// subjects of its signed provenance
+func signedArtifactsRequiredError() error {
+	return status.Error(codes.FailedPrecondition, "Signed provenance is required, built artifacts can only be recorded with IngestProvenance")
+}
+
+// unlinkedSbomError reports an SBOM occurrence that was recorded but couldn't be linked to its build occurrence. Rode
+// can't delete occurrences, so the id is included in the message and a ResourceInfo detail so that the SBOM can be
+// found and attached again. The code and details of err are kept.
+func unlinkedSbomError(err error, sbomOccurrenceId, buildOccurrenceId string) error {
+	s := status.Convert(err).Proto()
+	s.Message = fmt.Sprintf("SBOM occurrence %s was recorded but not linked to build occurrence %s: %s", sbomOccurrenceId, buildOccurrenceId, s.Message)
+
+	return withDetails(status.FromProto(s), &errdetails.ResourceInfo{
+		ResourceType: resourceTypeSbomOccurrence,
+		ResourceName: sbomOccurrenceId,
+		Description:  "recorded but not linked to build occurrence " + buildOccurrenceId,
+	}).Err()
+}
+
+// rodeError wraps an error returned by Rode, keeping its status code and attaching an ErrorInfo detail with a stable reason
+func rodeError(err error, reason, message string) *status.Status {
+	code := status.Code(err)
+
+	return withDetails(status.Newf(code, "%s: %s", message, err), &errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: errorDomain,
+		Metadata: map[string]string{
+			"rodeCode": code.String(),
+		},
+	})
+}
+
+// internalRodeError is used when a call to Rode succeeds, but the response isn't what the collector expected
+func internalRodeError(reason, message string) *status.Status {
+	return withDetails(status.New(codes.Internal, message), &errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: errorDomain,
+	})
+}
+
+// withDetails attaches details to a status, the original status is returned if they can't be marshalled
+func withDetails(s *status.Status, details ...protoiface.MessageV1) *status.Status {
+	detailed, err := s.WithDetails(details...)
+	if err != nil {
+		return s
+	}
+
+	return detailed
+}
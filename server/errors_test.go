@@ -0,0 +1,111 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("errors", func() {
+	Describe("invalidRequestError", func() {
+		It("should attach a field violation for a field error", func() {
+			err := invalidRequestError(newFieldError("artifacts[0].id", "artifact id must be specified"))
+
+			s := status.Convert(err)
+			Expect(s.Code()).To(Equal(codes.InvalidArgument))
+			Expect(s.Message()).To(Equal("Invalid request: artifact id must be specified"))
+			Expect(s.Details()).To(HaveLen(1))
+			Expect(s.Details()[0].(*errdetails.BadRequest).FieldViolations).To(ConsistOf(&errdetails.BadRequest_FieldViolation{
+				Field:       "artifacts[0].id",
+				Description: "artifact id must be specified",
+			}))
+		})
+
+		It("should find a field error that has been wrapped", func() {
+			err := invalidRequestError(fmt.Errorf("wrapped: %w", newFieldError("note", "unknown note")))
+
+			s := status.Convert(err)
+			Expect(s.Message()).To(Equal("Invalid request: wrapped: unknown note"))
+			Expect(s.Details()[0].(*errdetails.BadRequest).FieldViolations[0].Field).To(Equal("note"))
+		})
+
+		It("should not attach details when the field is unknown", func() {
+			s := status.Convert(invalidRequestError(errors.New("bad request")))
+
+			Expect(s.Code()).To(Equal(codes.InvalidArgument))
+			Expect(s.Message()).To(Equal("Invalid request: bad request"))
+			Expect(s.Details()).To(BeEmpty())
+		})
+	})
+
+	Describe("fieldErrorFrom", func() {
+		It("should attribute an error to the field", func() {
+			err := fieldErrorFrom("repository", errors.New("missing host"))
+
+			Expect(err).To(MatchError("missing host"))
+			Expect(err.(*fieldError).field).To(Equal("repository"))
+		})
+
+		It("should keep the path of an existing field error", func() {
+			err := fieldErrorFrom("artifacts[0]", newFieldError("artifacts[0].id", "invalid digest"))
+
+			Expect(err.(*fieldError).field).To(Equal("artifacts[0].id"))
+		})
+	})
+
+	Describe("notFoundError", func() {
+		It("should attach the resource info", func() {
+			s := status.Convert(notFoundError(resourceTypeArtifact, "sha256:abc", "No occurrence found for artifact: %s", "sha256:abc"))
+
+			Expect(s.Code()).To(Equal(codes.NotFound))
+			Expect(s.Message()).To(Equal("No occurrence found for artifact: sha256:abc"))
+			Expect(s.Details()).To(HaveLen(1))
+			resourceInfo := s.Details()[0].(*errdetails.ResourceInfo)
+			Expect(resourceInfo.ResourceType).To(Equal("artifact"))
+			Expect(resourceInfo.ResourceName).To(Equal("sha256:abc"))
+			Expect(resourceInfo.Description).To(Equal(s.Message()))
+		})
+	})
+
+	Describe("rodeError", func() {
+		It("should keep the status code from Rode and attach the reason", func() {
+			s := rodeError(status.Error(codes.Unavailable, "connection refused"), reasonRodeListOccurrencesFailed, "Error listing build occurrences in Rode")
+
+			Expect(s.Code()).To(Equal(codes.Unavailable))
+			Expect(s.Message()).To(Equal("Error listing build occurrences in Rode: rpc error: code = Unavailable desc = connection refused"))
+			Expect(s.Details()).To(HaveLen(1))
+			errorInfo := s.Details()[0].(*errdetails.ErrorInfo)
+			Expect(errorInfo.Reason).To(Equal("RODE_LIST_OCCURRENCES_FAILED"))
+			Expect(errorInfo.Domain).To(Equal(errorDomain))
+			Expect(errorInfo.Metadata).To(Equal(map[string]string{"rodeCode": "Unavailable"}))
+		})
+	})
+
+	Describe("internalRodeError", func() {
+		It("should be an internal error with the reason", func() {
+			s := internalRodeError(reasonRodeMissingOccurrenceData, "Occurrence data not returned from Rode")
+
+			Expect(s.Code()).To(Equal(codes.Internal))
+			Expect(s.Details()[0].(*errdetails.ErrorInfo).Reason).To(Equal("RODE_MISSING_OCCURRENCE_DATA"))
+		})
+	})
+})
@@ -0,0 +1,61 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"io"
+	"io/ioutil"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// rawBodyMarshaler is the gateway's default JSON marshaler, except that a request body bound to a bytes field is read
+// as it was sent, rather than as a base64 encoded JSON string. That's how ingested provenance documents are received.
+type rawBodyMarshaler struct {
+	runtime.Marshaler
+}
+
+// NewGatewayMarshaler returns the marshaler for the HTTP gateway
+func NewGatewayMarshaler() runtime.Marshaler {
+	return &rawBodyMarshaler{
+		Marshaler: &runtime.HTTPBodyMarshaler{
+			Marshaler: &runtime.JSONPb{
+				MarshalOptions: protojson.MarshalOptions{
+					EmitUnpopulated: true,
+				},
+				UnmarshalOptions: protojson.UnmarshalOptions{
+					DiscardUnknown: true,
+				},
+			},
+		},
+	}
+}
+
+func (m *rawBodyMarshaler) NewDecoder(r io.Reader) runtime.Decoder {
+	decoder := m.Marshaler.NewDecoder(r)
+
+	return runtime.DecoderFunc(func(v interface{}) error {
+		body, ok := v.(*[]byte)
+		if !ok {
+			return decoder.Decode(v)
+		}
+
+		var err error
+		*body, err = ioutil.ReadAll(r)
+
+		return err
+	})
+}
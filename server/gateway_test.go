@@ -0,0 +1,46 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rode/collector-build/proto/v1alpha1"
+)
+
+var _ = Describe("gateway", func() {
+	Describe("NewGatewayMarshaler", func() {
+		It("should read a body bound to a bytes field as it was sent", func() {
+			request := &v1alpha1.IngestProvenanceRequest{}
+
+			err := NewGatewayMarshaler().NewDecoder(strings.NewReader(slsaV02Statement)).Decode(&request.Document)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(request.Document).To(Equal([]byte(slsaV02Statement)))
+		})
+
+		It("should decode other bodies as JSON", func() {
+			request := &v1alpha1.AttachSbomRequest{}
+
+			err := NewGatewayMarshaler().NewDecoder(strings.NewReader(`{"artifactId": "sha256:abc", "document": "e30="}`)).Decode(request)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(request.ArtifactId).To(Equal("sha256:abc"))
+			Expect(request.Document).To(Equal([]byte("{}")))
+		})
+	})
+})
@@ -0,0 +1,409 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rode/collector-build/config"
+	"github.com/rode/collector-build/proto/v1alpha1"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/grafeas_go_proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	inTotoPayloadType = "application/vnd.in-toto+json"
+
+	// ingestedProvenanceBuildOption marks a build whose provenance bytes are the document that was ingested,
+	// recording whether that's the statement or its DSSE envelope
+	ingestedProvenanceBuildOption = "ingested_provenance"
+	ingestedInTotoStatement       = "statement"
+	ingestedDsseEnvelope          = "dsse_envelope"
+)
+
+// subjectDigestAlgorithms are the subject digests the collector can record, in order of preference
+var subjectDigestAlgorithms = []string{"sha256", "sha512", "sha1"}
+
+// provenanceDocument is the statement extracted from an ingested document, along with its envelope when it had one
+type provenanceDocument struct {
+	// the document as it was ingested, which is recorded as the build's provenance
+	raw       []byte
+	statement []byte
+	envelope  *dsseEnvelope
+	// path to the statement in the request, used in field violations
+	field string
+	// id of the trusted key that signed the envelope, when its signature was verified
+	keyId string
+}
+
+type ingestedStatement struct {
+	Type          string          `json:"_type"`
+	Subject       []inTotoSubject `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+type ingestedSlsaV02Predicate struct {
+	Builder    slsaV02Builder `json:"builder"`
+	Invocation struct {
+		ConfigSource slsaV02Material `json:"configSource"`
+	} `json:"invocation"`
+	Metadata struct {
+		BuildInvocationId string `json:"buildInvocationId"`
+		BuildStartedOn    string `json:"buildStartedOn"`
+		BuildFinishedOn   string `json:"buildFinishedOn"`
+	} `json:"metadata"`
+	Materials []slsaV02Material `json:"materials"`
+}
+
+type ingestedSlsaV1Predicate struct {
+	BuildDefinition struct {
+		ResolvedDependencies []slsaResourceDescriptor `json:"resolvedDependencies"`
+	} `json:"buildDefinition"`
+	RunDetails slsaV1RunDetails `json:"runDetails"`
+}
+
+// parseProvenanceDocument extracts the statement from a document that's either the statement itself or a DSSE envelope.
+// The document is kept as it was received, so that it can be recorded as the build's provenance.
+func parseProvenanceDocument(document []byte) (*provenanceDocument, error) {
+	if len(bytes.TrimSpace(document)) == 0 {
+		return nil, newFieldError("document", "document must be specified")
+	}
+
+	if !utf8.Valid(document) {
+		return nil, newFieldError("document", "document must be UTF-8 encoded JSON")
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(document, &fields); err != nil {
+		return nil, newFieldError("document", "invalid document: %s", err)
+	}
+
+	if _, ok := fields["payloadType"]; !ok {
+		return &provenanceDocument{raw: document, statement: document, field: "document"}, nil
+	}
+
+	envelope := &dsseEnvelope{}
+	if err := json.Unmarshal(document, envelope); err != nil {
+		return nil, newFieldError("document", "invalid DSSE envelope: %s", err)
+	}
+
+	if envelope.PayloadType != inTotoPayloadType {
+		return nil, newFieldError("document.payloadType", "unsupported payload type %q, expected %s", envelope.PayloadType, inTotoPayloadType)
+	}
+
+	statement, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return nil, newFieldError("document.payload", "payload is not valid base64: %s", err)
+	}
+
+	return &provenanceDocument{raw: document, statement: statement, envelope: envelope, field: "document.payload"}, nil
+}
+
+// buildOptions records the ingested document's form and the key that signed it on the build
+func (d *provenanceDocument) buildOptions() map[string]string {
+	options := map[string]string{ingestedProvenanceBuildOption: ingestedInTotoStatement}
+	if d.envelope != nil {
+		options[ingestedProvenanceBuildOption] = ingestedDsseEnvelope
+	}
+
+	if d.keyId != "" {
+		options[provenanceKeyIdBuildOption] = d.keyId
+	}
+
+	return options
+}
+
+// isIngestedProvenance reports whether the occurrence's provenance bytes are a document that was ingested,
+// rather than a statement generated by the collector
+func isIngestedProvenance(occurrence *grafeas_go_proto.Occurrence) bool {
+	_, ok := occurrence.GetBuild().GetProvenance().GetBuildOptions()[ingestedProvenanceBuildOption]
+
+	return ok
+}
+
+// provenanceStatement returns the statement recorded in the occurrence's provenance bytes, along with the DSSE
+// envelope it was ingested in, if any
+func provenanceStatement(occurrence *grafeas_go_proto.Occurrence) ([]byte, *structpb.Struct, error) {
+	provenanceBytes := []byte(occurrence.GetBuild().GetProvenanceBytes())
+	if occurrence.GetBuild().GetProvenance().GetBuildOptions()[ingestedProvenanceBuildOption] != ingestedDsseEnvelope {
+		return provenanceBytes, nil, nil
+	}
+
+	envelope := &structpb.Struct{}
+	if err := protojson.Unmarshal(provenanceBytes, envelope); err != nil {
+		return nil, nil, err
+	}
+
+	statement, err := base64.StdEncoding.DecodeString(envelope.GetFields()["payload"].GetStringValue())
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid envelope payload: %s", err)
+	}
+
+	return statement, envelope, nil
+}
+
+// verifyProvenanceDocument returns the id of the trusted key that signed the document, or an error if the document
+// isn't a DSSE envelope or none of its signatures verify
+func verifyProvenanceDocument(document *provenanceDocument, keys []*config.TrustedKey) (string, error) {
+	if document.envelope == nil {
+		return "", newFieldError("document", "provenance must be a DSSE envelope signed by a trusted key")
+	}
+
+	keyId, err := verifyDsseEnvelope(document.envelope, document.statement, keys)
+	if err != nil {
+		return "", fieldErrorFrom("document.signatures", err)
+	}
+
+	return keyId, nil
+}
+
+// mapStatementToCreateBuildRequest validates an in-toto statement with a SLSA provenance predicate and describes
+// the build it attests to: subjects become artifacts, and the builder, source and materials come from the predicate
+func mapStatementToCreateBuildRequest(document *provenanceDocument) (*v1alpha1.CreateBuildRequest, error) {
+	field := func(path string) string {
+		return document.field + "." + path
+	}
+
+	statement := &ingestedStatement{}
+	if err := json.Unmarshal(document.statement, statement); err != nil {
+		return nil, newFieldError(document.field, "invalid in-toto statement: %s", err)
+	}
+
+	if statement.Type != inTotoStatementV01Type && statement.Type != inTotoStatementV1Type {
+		return nil, newFieldError(field("_type"), "unsupported statement type %q", statement.Type)
+	}
+
+	if len(statement.Subject) == 0 {
+		return nil, newFieldError(field("subject"), "statement has no subjects")
+	}
+
+	request := &v1alpha1.CreateBuildRequest{}
+	for i, subject := range statement.Subject {
+		artifact, err := mapSubjectToArtifact(subject)
+		if err != nil {
+			return nil, newFieldError(field(fmt.Sprintf("subject[%d]", i)), "%s", err)
+		}
+
+		request.Artifacts = append(request.Artifacts, artifact)
+	}
+
+	var err error
+	switch statement.PredicateType {
+	case slsaV02PredicateType:
+		err = mapSlsaV02Predicate(statement.Predicate, request)
+	case slsaV1PredicateType:
+		err = mapSlsaV1Predicate(statement.Predicate, request)
+	default:
+		return nil, newFieldError(field("predicateType"), "unsupported predicate type %q, expected %s or %s", statement.PredicateType, slsaV02PredicateType, slsaV1PredicateType)
+	}
+
+	if err != nil {
+		if fieldErr, ok := err.(*fieldError); ok {
+			fieldErr.field = field(fieldErr.field)
+		}
+
+		return nil, err
+	}
+
+	return request, nil
+}
+
+func mapSubjectToArtifact(subject inTotoSubject) (*v1alpha1.Artifact, error) {
+	for _, algorithm := range subjectDigestAlgorithms {
+		value, ok := subject.Digest[algorithm]
+		if !ok {
+			continue
+		}
+
+		artifact := &v1alpha1.Artifact{
+			Id:     subject.Name,
+			Digest: &v1alpha1.Digest{Algorithm: algorithm, Hex: value},
+		}
+
+		if subject.Name == "" {
+			artifact.Id = algorithm + ":" + value
+		} else {
+			artifact.Names = []string{subject.Name}
+		}
+
+		return artifact, nil
+	}
+
+	return nil, fmt.Errorf("subject %q has no %s digest", subject.Name, strings.Join(subjectDigestAlgorithms, ", "))
+}
+
+func mapSlsaV02Predicate(predicateJson json.RawMessage, request *v1alpha1.CreateBuildRequest) error {
+	predicate := &ingestedSlsaV02Predicate{}
+	if err := json.Unmarshal(predicateJson, predicate); err != nil {
+		return newFieldError("predicate", "invalid SLSA v0.2 predicate: %s", err)
+	}
+
+	if predicate.Builder.Id == "" {
+		return newFieldError("predicate.builder.id", "builder id must be specified")
+	}
+	request.Builder = &v1alpha1.Builder{Id: predicate.Builder.Id}
+
+	configSource := predicate.Invocation.ConfigSource
+	if !mapGitSource(configSource.Uri, configSource.Digest, request) {
+		return newFieldError("predicate.invocation.configSource", "config source must be a git repository with a commit digest")
+	}
+
+	for _, material := range predicate.Materials {
+		if material.Uri != configSource.Uri {
+			request.Materials = append(request.Materials, &v1alpha1.Material{Uri: material.Uri, Digest: lowercaseDigestAlgorithms(material.Digest)})
+		}
+	}
+
+	request.ProvenanceId = predicate.Metadata.BuildInvocationId
+
+	var err error
+	if request.BuildStart, err = parseStatementTimestamp("predicate.metadata.buildStartedOn", predicate.Metadata.BuildStartedOn); err != nil {
+		return err
+	}
+
+	request.BuildEnd, err = parseStatementTimestamp("predicate.metadata.buildFinishedOn", predicate.Metadata.BuildFinishedOn)
+
+	return err
+}
+
+func mapSlsaV1Predicate(predicateJson json.RawMessage, request *v1alpha1.CreateBuildRequest) error {
+	predicate := &ingestedSlsaV1Predicate{}
+	if err := json.Unmarshal(predicateJson, predicate); err != nil {
+		return newFieldError("predicate", "invalid SLSA v1 predicate: %s", err)
+	}
+
+	builder := predicate.RunDetails.Builder
+	if builder.Id == "" {
+		return newFieldError("predicate.runDetails.builder.id", "builder id must be specified")
+	}
+	request.Builder = &v1alpha1.Builder{Id: builder.Id, Version: builder.Version["builder"]}
+
+	foundSource := false
+	for _, dependency := range predicate.BuildDefinition.ResolvedDependencies {
+		if !foundSource && mapGitSource(dependency.Uri, dependency.Digest, request) {
+			foundSource = true
+			continue
+		}
+
+		if dependency.Uri != "" {
+			request.Materials = append(request.Materials, &v1alpha1.Material{Uri: dependency.Uri, Digest: lowercaseDigestAlgorithms(dependency.Digest)})
+		}
+	}
+
+	if !foundSource {
+		return newFieldError("predicate.buildDefinition.resolvedDependencies", "no git repository with a commit digest found in the resolved dependencies")
+	}
+
+	for _, byproduct := range predicate.RunDetails.Byproducts {
+		if byproduct.Name == "logs" {
+			request.LogsUri = byproduct.Uri
+		}
+	}
+
+	metadata := predicate.RunDetails.Metadata
+	request.ProvenanceId = metadata.InvocationId
+
+	var err error
+	if request.BuildStart, err = parseStatementTimestamp("predicate.runDetails.metadata.startedOn", metadata.StartedOn); err != nil {
+		return err
+	}
+
+	request.BuildEnd, err = parseStatementTimestamp("predicate.runDetails.metadata.finishedOn", metadata.FinishedOn)
+
+	return err
+}
+
+// mapGitSource sets the repository, commit and ref of the request from a SLSA source uri like
+// git+https://github.com/rode/collector-build@refs/heads/main. It reports whether the uri was a git source with a commit.
+func mapGitSource(uri string, digest map[string]string, request *v1alpha1.CreateBuildRequest) bool {
+	if !strings.HasPrefix(uri, "git+") {
+		return false
+	}
+
+	commitId := ""
+	for _, algorithm := range []string{"gitCommit", "sha1", "sha256"} {
+		if commitId = digest[algorithm]; commitId != "" {
+			break
+		}
+	}
+
+	if commitId == "" {
+		return false
+	}
+
+	repository, err := url.Parse(strings.TrimPrefix(uri, "git+"))
+	if err != nil {
+		return false
+	}
+
+	ref := ""
+	if i := strings.Index(repository.Path, "@"); i != -1 {
+		repository.Path, ref = repository.Path[:i], repository.Path[i+1:]
+		repository.RawPath = ""
+	}
+
+	request.Repository = repository.String()
+	request.CommitId = commitId
+
+	git := &v1alpha1.GitSource{}
+	switch {
+	case strings.HasPrefix(ref, "refs/heads/"):
+		git.RevisionAlias = &v1alpha1.GitSource_Branch{Branch: strings.TrimPrefix(ref, "refs/heads/")}
+	case strings.HasPrefix(ref, "refs/tags/"):
+		git.RevisionAlias = &v1alpha1.GitSource_Tag{Tag: strings.TrimPrefix(ref, "refs/tags/")}
+	case ref != "":
+		git.RevisionAlias = &v1alpha1.GitSource_Ref{Ref: ref}
+	}
+	request.Source = &v1alpha1.CreateBuildRequest_Git{Git: git}
+
+	return true
+}
+
+// lowercaseDigestAlgorithms converts in-toto digest names like gitCommit to the lowercase form used by materials
+func lowercaseDigestAlgorithms(digest map[string]string) map[string]string {
+	if len(digest) == 0 {
+		return nil
+	}
+
+	lowercased := map[string]string{}
+	for algorithm, value := range digest {
+		lowercased[strings.ToLower(algorithm)] = value
+	}
+
+	return lowercased
+}
+
+func parseStatementTimestamp(field, value string) (*timestamppb.Timestamp, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, newFieldError(field, "invalid timestamp %q, expected RFC 3339", value)
+	}
+
+	return timestamppb.New(t), nil
+}
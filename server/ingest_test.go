@@ -0,0 +1,267 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/rode/collector-build/config"
+	"github.com/rode/collector-build/proto/v1alpha1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	ingestDigest   = strings.Repeat("ef", 32)
+	ingestCommitId = strings.Repeat("2", 40)
+
+	slsaV02Statement = `{
+		"_type": "https://in-toto.io/Statement/v0.1",
+		"predicateType": "https://slsa.dev/provenance/v0.2",
+		"subject": [{"name": "binary-linux-amd64", "digest": {"sha256": "` + ingestDigest + `"}}],
+		"predicate": {
+			"builder": {"id": "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v1.2.0"},
+			"buildType": "https://github.com/slsa-framework/slsa-github-generator/generic@v1",
+			"invocation": {
+				"configSource": {
+					"uri": "git+https://github.com/rode/collector-build@refs/heads/main",
+					"digest": {"sha1": "` + ingestCommitId + `"},
+					"entryPoint": ".github/workflows/release.yml"
+				},
+				"parameters": {},
+				"environment": {"github_run_id": "1234"}
+			},
+			"metadata": {
+				"buildInvocationId": "1234-1",
+				"buildStartedOn": "2021-06-01T12:00:00Z",
+				"buildFinishedOn": "2021-06-01T12:05:00Z",
+				"completeness": {"parameters": true, "environment": false, "materials": false},
+				"reproducible": false
+			},
+			"materials": [
+				{"uri": "git+https://github.com/rode/collector-build@refs/heads/main", "digest": {"sha1": "` + ingestCommitId + `"}},
+				{"uri": "git+https://github.com/actions/checkout@v2", "digest": {"gitCommit": "` + strings.Repeat("3", 40) + `"}}
+			]
+		}
+	}`
+
+	slsaV1Statement = `{
+		"_type": "https://in-toto.io/Statement/v1",
+		"predicateType": "https://slsa.dev/provenance/v1",
+		"subject": [{"name": "ghcr.io/rode/collector-build", "digest": {"sha512": "` + strings.Repeat("a", 128) + `", "sha256": "` + ingestDigest + `"}}],
+		"predicate": {
+			"buildDefinition": {
+				"buildType": "https://slsa-framework.github.io/github-actions-buildtypes/workflow/v1",
+				"externalParameters": {"workflow": {"ref": "refs/tags/v1.0.0", "repository": "https://github.com/rode/collector-build", "path": ".github/workflows/release.yml"}},
+				"resolvedDependencies": [
+					{"uri": "git+https://github.com/rode/collector-build@refs/tags/v1.0.0", "digest": {"gitCommit": "` + ingestCommitId + `"}},
+					{"uri": "pkg:docker/golang@1.17", "digest": {"sha256": "` + ingestDigest + `"}},
+					{"uri": "pkg:golang/go.uber.org/zap@v1.19.0", "digest": {"dirHash": "h1:4JbCOapHGdbP1Cy7Yc7A/TAXUXQMkEZc5IGVI8uUsjA="}}
+				]
+			},
+			"runDetails": {
+				"builder": {"id": "https://github.com/actions/runner", "version": {"builder": "2.280.0"}},
+				"metadata": {"invocationId": "https://github.com/rode/collector-build/actions/runs/1234/attempts/1", "startedOn": "2021-06-01T12:00:00Z"},
+				"byproducts": [{"name": "logs", "uri": "https://github.com/rode/collector-build/actions/runs/1234"}]
+			}
+		}
+	}`
+)
+
+func newProvenanceDocument(statement string) *provenanceDocument {
+	return &provenanceDocument{statement: []byte(statement), field: "document"}
+}
+
+var _ = Describe("ingest", func() {
+	Describe("parseProvenanceDocument", func() {
+		It("should use a statement as is", func() {
+			document, err := parseProvenanceDocument([]byte(slsaV02Statement))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(document.envelope).To(BeNil())
+			Expect(document.field).To(Equal("document"))
+			Expect(document.statement).To(MatchJSON(slsaV02Statement))
+		})
+
+		It("should keep the document as it was received", func() {
+			document, err := parseProvenanceDocument([]byte(slsaV02Statement))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(document.raw).To(Equal([]byte(slsaV02Statement)))
+		})
+
+		It("should decode the payload of a DSSE envelope", func() {
+			envelope := `{
+				"payloadType": "application/vnd.in-toto+json",
+				"payload": "` + base64.StdEncoding.EncodeToString([]byte(slsaV02Statement)) + `",
+				"signatures": [{"keyid": "release", "sig": "c2ln"}]
+			}`
+
+			document, err := parseProvenanceDocument([]byte(envelope))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(document.statement).To(Equal([]byte(slsaV02Statement)))
+			Expect(document.field).To(Equal("document.payload"))
+			Expect(document.envelope.Signatures).To(ConsistOf(dsseSignature{KeyId: "release", Sig: "c2ln"}))
+		})
+
+		DescribeTable("invalid documents", func(document, expectedField string) {
+			_, err := parseProvenanceDocument([]byte(document))
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*fieldError).field).To(Equal(expectedField))
+		},
+			Entry("missing", "", "document"),
+			Entry("not JSON", "statement", "document"),
+			Entry("not an object", `["statement"]`, "document"),
+			Entry("not UTF-8", "{\"_type\": \"\xff\"}", "document"),
+			Entry("unsupported payload type", `{"payloadType": "application/json", "payload": ""}`, "document.payloadType"),
+			Entry("payload not base64", `{"payloadType": "application/vnd.in-toto+json", "payload": "%%%"}`, "document.payload"),
+		)
+	})
+
+	Describe("verifyProvenanceDocument", func() {
+		It("should require an envelope", func() {
+			_, err := verifyProvenanceDocument(newProvenanceDocument(slsaV02Statement), nil)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*fieldError).field).To(Equal("document"))
+		})
+
+		It("should return the id of the key that signed the envelope", func() {
+			publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+			document, err := parseProvenanceDocument([]byte(newSignedEnvelope(slsaV02Statement, "", ed25519Signer(privateKey))))
+			Expect(err).NotTo(HaveOccurred())
+
+			keyId, err := verifyProvenanceDocument(document, []*config.TrustedKey{{Id: "release", PublicKey: publicKey}})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keyId).To(Equal("release"))
+		})
+	})
+
+	Describe("mapStatementToCreateBuildRequest", func() {
+		It("should map a SLSA v0.2 statement", func() {
+			request, err := mapStatementToCreateBuildRequest(newProvenanceDocument(slsaV02Statement))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(request, &v1alpha1.CreateBuildRequest{
+				Repository: "https://github.com/rode/collector-build",
+				CommitId:   ingestCommitId,
+				Artifacts: []*v1alpha1.Artifact{
+					{
+						Id:     "binary-linux-amd64",
+						Names:  []string{"binary-linux-amd64"},
+						Digest: &v1alpha1.Digest{Algorithm: "sha256", Hex: ingestDigest},
+					},
+				},
+				ProvenanceId: "1234-1",
+				BuildStart:   timestamppb.New(time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)),
+				BuildEnd:     timestamppb.New(time.Date(2021, 6, 1, 12, 5, 0, 0, time.UTC)),
+				Materials: []*v1alpha1.Material{
+					{Uri: "git+https://github.com/actions/checkout@v2", Digest: map[string]string{"gitcommit": strings.Repeat("3", 40)}},
+				},
+				Builder: &v1alpha1.Builder{Id: "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v1.2.0"},
+				Source: &v1alpha1.CreateBuildRequest_Git{
+					Git: &v1alpha1.GitSource{RevisionAlias: &v1alpha1.GitSource_Branch{Branch: "main"}},
+				},
+			})).To(BeTrue(), "unexpected request %v", request)
+		})
+
+		It("should map a SLSA v1 statement", func() {
+			request, err := mapStatementToCreateBuildRequest(newProvenanceDocument(slsaV1Statement))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(request, &v1alpha1.CreateBuildRequest{
+				Repository: "https://github.com/rode/collector-build",
+				CommitId:   ingestCommitId,
+				Artifacts: []*v1alpha1.Artifact{
+					{
+						Id:     "ghcr.io/rode/collector-build",
+						Names:  []string{"ghcr.io/rode/collector-build"},
+						Digest: &v1alpha1.Digest{Algorithm: "sha256", Hex: ingestDigest},
+					},
+				},
+				ProvenanceId: "https://github.com/rode/collector-build/actions/runs/1234/attempts/1",
+				LogsUri:      "https://github.com/rode/collector-build/actions/runs/1234",
+				BuildStart:   timestamppb.New(time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)),
+				Materials: []*v1alpha1.Material{
+					{Uri: "pkg:docker/golang@1.17", Digest: map[string]string{"sha256": ingestDigest}},
+					{Uri: "pkg:golang/go.uber.org/zap@v1.19.0", Digest: map[string]string{"dirhash": "h1:4JbCOapHGdbP1Cy7Yc7A/TAXUXQMkEZc5IGVI8uUsjA="}},
+				},
+				Builder: &v1alpha1.Builder{Id: "https://github.com/actions/runner", Version: "2.280.0"},
+				Source: &v1alpha1.CreateBuildRequest_Git{
+					Git: &v1alpha1.GitSource{RevisionAlias: &v1alpha1.GitSource_Tag{Tag: "v1.0.0"}},
+				},
+			})).To(BeTrue(), "unexpected request %v", request)
+		})
+
+		It("should read back a statement generated by the collector", func() {
+			statement := strings.Replace(slsaV1Statement, "git+https://github.com/rode/collector-build@refs/tags/v1.0.0", "git+https://github.com/rode/collector-build", 1)
+
+			request, err := mapStatementToCreateBuildRequest(newProvenanceDocument(statement))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(request.Repository).To(Equal("https://github.com/rode/collector-build"))
+			Expect(request.GetGit().GetRevisionAlias()).To(BeNil())
+		})
+
+		DescribeTable("invalid statements", func(modify func(string) string, expectedField string) {
+			_, err := mapStatementToCreateBuildRequest(newProvenanceDocument(modify(slsaV02Statement)))
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*fieldError).field).To(Equal(expectedField))
+		},
+			Entry("not json", func(string) string { return "{" }, "document"),
+			Entry("unknown statement type", func(s string) string {
+				return strings.Replace(s, "https://in-toto.io/Statement/v0.1", "https://example.com/Statement", 1)
+			}, "document._type"),
+			Entry("no subjects", func(s string) string {
+				return strings.Replace(s, `[{"name": "binary-linux-amd64", "digest": {"sha256": "`+ingestDigest+`"}}]`, "[]", 1)
+			}, "document.subject"),
+			Entry("subject without a supported digest", func(s string) string {
+				return strings.Replace(s, `{"sha256": "`+ingestDigest+`"}`, `{"md5": "abc"}`, 1)
+			}, "document.subject[0]"),
+			Entry("unknown predicate type", func(s string) string {
+				return strings.Replace(s, "https://slsa.dev/provenance/v0.2", "https://slsa.dev/provenance/v0.1", 1)
+			}, "document.predicateType"),
+			Entry("missing builder", func(s string) string {
+				return strings.Replace(s, `"builder": {"id": "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v1.2.0"},`, "", 1)
+			}, "document.predicate.builder.id"),
+			Entry("config source without a commit", func(s string) string {
+				return strings.Replace(s, `"digest": {"sha1": "`+ingestCommitId+`"},`, "", 1)
+			}, "document.predicate.invocation.configSource"),
+			Entry("invalid timestamp", func(s string) string {
+				return strings.Replace(s, "2021-06-01T12:00:00Z", "yesterday", 1)
+			}, "document.predicate.metadata.buildStartedOn"),
+		)
+
+		It("should require a git source in a SLSA v1 statement", func() {
+			statement := strings.Replace(slsaV1Statement, `"gitCommit": "`+ingestCommitId+`"`, "", 1)
+
+			_, err := mapStatementToCreateBuildRequest(newProvenanceDocument(statement))
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*fieldError).field).To(Equal("document.predicate.buildDefinition.resolvedDependencies"))
+		})
+	})
+})
@@ -0,0 +1,88 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sort"
+	"sync"
+)
+
+// occurrenceLocks serializes artifact updates to the same build occurrence within this collector
+type occurrenceLocks struct {
+	mu    sync.Mutex
+	locks map[string]*occurrenceLock
+}
+
+type occurrenceLock struct {
+	sync.Mutex
+	waiters int
+}
+
+func newOccurrenceLocks() *occurrenceLocks {
+	return &occurrenceLocks{
+		locks: map[string]*occurrenceLock{},
+	}
+}
+
+// lock blocks until the caller holds the lock for the occurrence, and returns a function to release it.
+// Locks are removed once no callers are holding or waiting on them.
+func (o *occurrenceLocks) lock(occurrenceId string) func() {
+	o.mu.Lock()
+	l, ok := o.locks[occurrenceId]
+	if !ok {
+		l = &occurrenceLock{}
+		o.locks[occurrenceId] = l
+	}
+	l.waiters++
+	o.mu.Unlock()
+
+	l.Lock()
+
+	return func() {
+		l.Unlock()
+
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		l.waiters--
+		if l.waiters == 0 {
+			delete(o.locks, occurrenceId)
+		}
+	}
+}
+
+// lockAll acquires the locks for each of the ids in sorted order, so that callers locking overlapping sets can't
+// deadlock, and returns a function to release all of them.
+func (o *occurrenceLocks) lockAll(ids []string) func() {
+	sorted := make([]string, 0, len(ids))
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			sorted = append(sorted, id)
+		}
+	}
+	sort.Strings(sorted)
+
+	unlocks := make([]func(), 0, len(sorted))
+	for _, id := range sorted {
+		unlocks = append(unlocks, o.lock(id))
+	}
+
+	return func() {
+		for i := len(unlocks) - 1; i >= 0; i-- {
+			unlocks[i]()
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("occurrenceLocks", func() {
+	var locks *occurrenceLocks
+
+	BeforeEach(func() {
+		locks = newOccurrenceLocks()
+	})
+
+	It("should serialize callers locking the same occurrence", func() {
+		var (
+			wg      sync.WaitGroup
+			active  int
+			maximum int
+			counter sync.Mutex
+		)
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				unlock := locks.lock("foo")
+				defer unlock()
+
+				counter.Lock()
+				active++
+				if active > maximum {
+					maximum = active
+				}
+				counter.Unlock()
+
+				time.Sleep(time.Millisecond)
+
+				counter.Lock()
+				active--
+				counter.Unlock()
+			}()
+		}
+		wg.Wait()
+
+		Expect(maximum).To(Equal(1))
+	})
+
+	It("should not block callers locking different occurrences", func() {
+		unlockFoo := locks.lock("foo")
+		defer unlockFoo()
+
+		done := make(chan struct{})
+		go func() {
+			unlock := locks.lock("bar")
+			unlock()
+			close(done)
+		}()
+
+		Eventually(done).Should(BeClosed())
+	})
+
+	It("should release locks that are no longer in use", func() {
+		unlock := locks.lock("foo")
+		Expect(locks.locks).To(HaveKey("foo"))
+
+		unlock()
+		Expect(locks.locks).To(BeEmpty())
+	})
+
+	It("should lock overlapping sets of occurrences without deadlocking", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			ids := []string{"foo", "bar", "baz", "foo"}
+			if i%2 == 0 {
+				ids = []string{"baz", "bar", "foo"}
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				unlock := locks.lockAll(ids)
+				time.Sleep(time.Millisecond)
+				unlock()
+			}()
+		}
+
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+
+		Eventually(done).Should(BeClosed())
+		Expect(locks.locks).To(BeEmpty())
+	})
+})
@@ -0,0 +1,172 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rode/collector-build/proto/v1alpha1"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/provenance_go_proto"
+)
+
+var digestAlgorithmPattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// hexDigestAlgorithms are the material digest algorithms whose values are hex encoded. Values of other algorithms,
+// like the base64 dirhash of a Go module, are kept as they were given.
+var hexDigestAlgorithms = map[string]bool{
+	"md5":        true,
+	"sha1":       true,
+	"sha224":     true,
+	"sha256":     true,
+	"sha384":     true,
+	"sha512":     true,
+	"sha512_224": true,
+	"sha512_256": true,
+	"sha3_224":   true,
+	"sha3_256":   true,
+	"sha3_384":   true,
+	"sha3_512":   true,
+	"gitcommit":  true,
+	"gittree":    true,
+	"gitblob":    true,
+	"gittag":     true,
+}
+
+func validateMaterials(materials []*v1alpha1.Material) error {
+	for i, material := range materials {
+		if len(material.Uri) == 0 {
+			return newFieldError(fmt.Sprintf("materials[%d].uri", i), "material uri must be specified")
+		}
+
+		for algorithm, value := range material.Digest {
+			if !digestAlgorithmPattern.MatchString(algorithm) {
+				return newFieldError(fmt.Sprintf("materials[%d].digest", i), "invalid digest algorithm %q for material %s", algorithm, material.Uri)
+			}
+
+			if len(value) == 0 || !isValidDigestValue(algorithm, value) {
+				return newFieldError(fmt.Sprintf("materials[%d].digest", i), "invalid %s digest for material %s", algorithm, material.Uri)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isValidDigestValue reports whether the value is valid for the algorithm, only values of hex algorithms are checked
+func isValidDigestValue(algorithm, value string) bool {
+	if !hexDigestAlgorithms[algorithm] {
+		return true
+	}
+
+	_, err := hex.DecodeString(value)
+
+	return err == nil
+}
+
+// mapMaterialsToFileHashes records build materials as source file hashes, keyed by the material URI.
+// Grafeas only has a hash type for SHA-256, which holds the digest bytes. Other digests are stored as "algorithm:value"
+// with an unspecified hash type, so that the algorithm isn't lost.
+func mapMaterialsToFileHashes(materials []*v1alpha1.Material) map[string]*provenance_go_proto.FileHashes {
+	if len(materials) == 0 {
+		return nil
+	}
+
+	fileHashes := map[string]*provenance_go_proto.FileHashes{}
+	for _, material := range materials {
+		hashes, ok := fileHashes[material.Uri]
+		if !ok {
+			hashes = &provenance_go_proto.FileHashes{}
+			fileHashes[material.Uri] = hashes
+		}
+
+		var algorithms []string
+		for algorithm := range material.Digest {
+			algorithms = append(algorithms, algorithm)
+		}
+		sort.Strings(algorithms)
+
+		for _, algorithm := range algorithms {
+			value := material.Digest[algorithm]
+			if hexDigestAlgorithms[algorithm] {
+				value = strings.ToLower(value)
+			}
+			hash := &provenance_go_proto.Hash{
+				Type:  provenance_go_proto.Hash_HASH_TYPE_UNSPECIFIED,
+				Value: []byte(fmt.Sprintf("%s:%s", algorithm, value)),
+			}
+
+			if algorithm == "sha256" {
+				if digest, err := hex.DecodeString(value); err == nil {
+					hash.Type = provenance_go_proto.Hash_SHA256
+					hash.Value = digest
+				}
+			}
+
+			hashes.FileHash = append(hashes.FileHash, hash)
+		}
+	}
+
+	return fileHashes
+}
+
+func mapFileHashesToMaterials(fileHashes map[string]*provenance_go_proto.FileHashes) []*v1alpha1.Material {
+	var uris []string
+	for uri := range fileHashes {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+
+	var materials []*v1alpha1.Material
+	for _, uri := range uris {
+		material := &v1alpha1.Material{
+			Uri:    uri,
+			Digest: map[string]string{},
+		}
+
+		for _, hash := range fileHashes[uri].GetFileHash() {
+			algorithm, value := parseFileHashValue(hash.Value)
+			if hash.Type == provenance_go_proto.Hash_SHA256 && algorithm != "sha256" {
+				algorithm, value = "sha256", hex.EncodeToString(hash.Value)
+			}
+
+			if algorithm != "" {
+				material.Digest[algorithm] = value
+			}
+		}
+
+		materials = append(materials, material)
+	}
+
+	return materials
+}
+
+// parseFileHashValue splits a file hash value stored as "algorithm:value". SHA-256 hashes recorded before the digest
+// bytes were stored directly also use this form.
+func parseFileHashValue(value []byte) (string, string) {
+	parts := strings.SplitN(string(value), ":", 2)
+	if len(parts) != 2 || !digestAlgorithmPattern.MatchString(parts[0]) {
+		return "", ""
+	}
+
+	if len(parts[1]) == 0 || !isValidDigestValue(parts[0], parts[1]) {
+		return "", ""
+	}
+
+	return parts[0], parts[1]
+}
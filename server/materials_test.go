@@ -0,0 +1,143 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/rode/collector-build/proto/v1alpha1"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/provenance_go_proto"
+)
+
+var _ = Describe("materials", func() {
+	Describe("validateMaterials", func() {
+		DescribeTable("invalid materials", func(material *v1alpha1.Material) {
+			Expect(validateMaterials([]*v1alpha1.Material{material})).To(HaveOccurred())
+		},
+			Entry("missing uri", &v1alpha1.Material{Digest: map[string]string{"sha256": "abcd"}}),
+			Entry("uppercase algorithm", &v1alpha1.Material{Uri: "pkg:golang/foo", Digest: map[string]string{"SHA256": "abcd"}}),
+			Entry("empty digest", &v1alpha1.Material{Uri: "pkg:golang/foo", Digest: map[string]string{"sha256": ""}}),
+			Entry("non-hex digest", &v1alpha1.Material{Uri: "pkg:golang/foo", Digest: map[string]string{"sha256": "xyz"}}),
+		)
+
+		It("should allow digests that aren't hex encoded", func() {
+			material := &v1alpha1.Material{
+				Uri:    "pkg:golang/go.uber.org/zap@v1.16.0",
+				Digest: map[string]string{"dirhash": "h1:4JbCOapHGdbP1Cy7Yc7A/TAXUXQMkEZc5IGVI8uUsjA="},
+			}
+
+			Expect(validateMaterials([]*v1alpha1.Material{material})).To(Succeed())
+		})
+
+		It("should allow materials without digests", func() {
+			Expect(validateMaterials([]*v1alpha1.Material{{Uri: "https://example.com/archive.tgz"}})).To(Succeed())
+		})
+	})
+
+	Describe("mapping", func() {
+		var materials []*v1alpha1.Material
+
+		BeforeEach(func() {
+			materials = []*v1alpha1.Material{
+				{
+					Uri: "docker.io/library/golang",
+					Digest: map[string]string{
+						"sha256": "ABCDEF0123",
+						"sha512": "0123abcdef",
+					},
+				},
+				{
+					Uri: "pkg:golang/go.uber.org/zap@v1.16.0",
+					Digest: map[string]string{
+						"sha1": "aabbcc",
+					},
+				},
+			}
+		})
+
+		It("should store each digest as a file hash keyed by the material uri", func() {
+			fileHashes := mapMaterialsToFileHashes(materials)
+
+			Expect(fileHashes).To(HaveLen(2))
+			Expect(fileHashes["docker.io/library/golang"].FileHash).To(Equal([]*provenance_go_proto.Hash{
+				{
+					Type:  provenance_go_proto.Hash_SHA256,
+					Value: []byte{0xab, 0xcd, 0xef, 0x01, 0x23},
+				},
+				{
+					Type:  provenance_go_proto.Hash_HASH_TYPE_UNSPECIFIED,
+					Value: []byte("sha512:0123abcdef"),
+				},
+			}))
+		})
+
+		It("should map file hashes back to materials", func() {
+			actualMaterials := mapFileHashesToMaterials(mapMaterialsToFileHashes(materials))
+
+			Expect(actualMaterials).To(HaveLen(2))
+			Expect(actualMaterials[0].Uri).To(Equal("docker.io/library/golang"))
+			Expect(actualMaterials[0].Digest).To(Equal(map[string]string{
+				"sha256": "abcdef0123",
+				"sha512": "0123abcdef",
+			}))
+			Expect(actualMaterials[1].Uri).To(Equal("pkg:golang/go.uber.org/zap@v1.16.0"))
+			Expect(actualMaterials[1].Digest).To(Equal(map[string]string{
+				"sha1": "aabbcc",
+			}))
+		})
+
+		It("should read SHA-256 hashes stored with their algorithm", func() {
+			actualMaterials := mapFileHashesToMaterials(map[string]*provenance_go_proto.FileHashes{
+				"docker.io/library/golang": {
+					FileHash: []*provenance_go_proto.Hash{
+						{
+							Type:  provenance_go_proto.Hash_SHA256,
+							Value: []byte("sha256:abcdef0123"),
+						},
+					},
+				},
+			})
+
+			Expect(actualMaterials).To(HaveLen(1))
+			Expect(actualMaterials[0].Digest).To(Equal(map[string]string{
+				"sha256": "abcdef0123",
+			}))
+		})
+
+		It("should keep digests that aren't hex encoded as they were given", func() {
+			materials = []*v1alpha1.Material{
+				{
+					Uri:    "pkg:golang/go.uber.org/zap@v1.16.0",
+					Digest: map[string]string{"dirhash": "h1:4JbCOapHGdbP1Cy7Yc7A/TAXUXQMkEZc5IGVI8uUsjA="},
+				},
+			}
+
+			fileHashes := mapMaterialsToFileHashes(materials)
+
+			Expect(fileHashes["pkg:golang/go.uber.org/zap@v1.16.0"].FileHash).To(Equal([]*provenance_go_proto.Hash{
+				{
+					Type:  provenance_go_proto.Hash_HASH_TYPE_UNSPECIFIED,
+					Value: []byte("dirhash:h1:4JbCOapHGdbP1Cy7Yc7A/TAXUXQMkEZc5IGVI8uUsjA="),
+				},
+			}))
+			Expect(mapFileHashesToMaterials(fileHashes)[0].Digest).To(Equal(materials[0].Digest))
+		})
+
+		It("should not set file hashes when there are no materials", func() {
+			Expect(mapMaterialsToFileHashes(nil)).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,328 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rode/collector-build/proto/v1alpha1"
+)
+
+const purlScheme = "pkg:"
+
+var (
+	purlTypePattern         = regexp.MustCompile(`^[a-z.+-][a-z0-9.+-]*$`)
+	purlQualifierKeyPattern = regexp.MustCompile(`^[a-z.\-_][a-z0-9.\-_]*$`)
+)
+
+// packageURL is a parsed Package URL, see https://github.com/package-url/purl-spec
+type packageURL struct {
+	Type       string
+	Namespace  []string
+	Name       string
+	Version    string
+	Qualifiers map[string]string
+	Subpath    string
+}
+
+func isPackageURL(id string) bool {
+	return strings.HasPrefix(strings.ToLower(id), purlScheme)
+}
+
+func parsePackageURL(purl string) (*packageURL, error) {
+	if !isPackageURL(purl) {
+		return nil, fmt.Errorf("package url must start with %s", purlScheme)
+	}
+	remainder := strings.TrimLeft(purl[len(purlScheme):], "/")
+
+	p := &packageURL{}
+	if i := strings.LastIndex(remainder, "#"); i != -1 {
+		var segments []string
+		for _, segment := range strings.Split(remainder[i+1:], "/") {
+			if segment == "" || segment == "." || segment == ".." {
+				continue
+			}
+
+			unescaped, err := url.PathUnescape(segment)
+			if err != nil {
+				return nil, fmt.Errorf("invalid package url subpath: %s", err)
+			}
+			segments = append(segments, unescaped)
+		}
+		p.Subpath = strings.Join(segments, "/")
+		remainder = remainder[:i]
+	}
+
+	if i := strings.LastIndex(remainder, "?"); i != -1 {
+		qualifiers, err := parsePackageURLQualifiers(remainder[i+1:])
+		if err != nil {
+			return nil, err
+		}
+		p.Qualifiers = qualifiers
+		remainder = remainder[:i]
+	}
+
+	remainder = strings.TrimRight(remainder, "/")
+	if i := strings.LastIndex(remainder, "@"); i != -1 && i > strings.LastIndex(remainder, "/") {
+		version, err := url.PathUnescape(remainder[i+1:])
+		if err != nil || version == "" {
+			return nil, errors.New("invalid package url version")
+		}
+		p.Version = version
+		remainder = remainder[:i]
+	}
+
+	segments := strings.Split(remainder, "/")
+	if len(segments) < 2 {
+		return nil, errors.New("package url must have a type and a name")
+	}
+
+	p.Type = strings.ToLower(segments[0])
+	if !purlTypePattern.MatchString(p.Type) {
+		return nil, fmt.Errorf("invalid package url type %q", segments[0])
+	}
+
+	for i, segment := range segments[1:] {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil || unescaped == "" {
+			return nil, errors.New("package url has an empty or invalid namespace or name")
+		}
+
+		if i == len(segments)-2 {
+			p.Name = unescaped
+		} else {
+			p.Namespace = append(p.Namespace, unescaped)
+		}
+	}
+
+	p.normalize()
+
+	return p, nil
+}
+
+func parsePackageURLQualifiers(query string) (map[string]string, error) {
+	if query == "" {
+		return nil, nil
+	}
+
+	qualifiers := map[string]string{}
+	for _, pair := range strings.Split(query, "&") {
+		pieces := strings.SplitN(pair, "=", 2)
+		if len(pieces) != 2 {
+			return nil, fmt.Errorf("invalid package url qualifier %q", pair)
+		}
+
+		key := strings.ToLower(pieces[0])
+		if !purlQualifierKeyPattern.MatchString(key) {
+			return nil, fmt.Errorf("invalid package url qualifier key %q", pieces[0])
+		}
+
+		if _, ok := qualifiers[key]; ok {
+			return nil, fmt.Errorf("duplicate package url qualifier %q", key)
+		}
+
+		value, err := url.PathUnescape(pieces[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid package url qualifier value for %s", key)
+		}
+
+		// qualifiers with empty values are treated as absent
+		if value != "" {
+			qualifiers[key] = value
+		}
+	}
+
+	return qualifiers, nil
+}
+
+// normalize applies the type-specific rules from the purl spec for the package types the collector knows about
+func (p *packageURL) normalize() {
+	switch p.Type {
+	case "github", "bitbucket", "npm":
+		p.Name = strings.ToLower(p.Name)
+		for i := range p.Namespace {
+			p.Namespace[i] = strings.ToLower(p.Namespace[i])
+		}
+	case "pypi":
+		p.Name = strings.ReplaceAll(strings.ToLower(p.Name), "_", "-")
+	}
+}
+
+// addChecksum records a normalized digest in the checksum qualifier, failing if a different checksum
+// for the same algorithm is already present
+func (p *packageURL) addChecksum(digest string) error {
+	var checksums []string
+	if p.Qualifiers["checksum"] != "" {
+		checksums = strings.Split(p.Qualifiers["checksum"], ",")
+	}
+
+	algorithm := strings.SplitN(digest, ":", 2)[0]
+	for _, checksum := range checksums {
+		existing, err := normalizeDigestString(checksum)
+		if err != nil {
+			return err
+		}
+
+		if existing == digest {
+			return nil
+		}
+
+		if strings.HasPrefix(existing, algorithm+":") {
+			return fmt.Errorf("package url already has a different %s checksum", algorithm)
+		}
+	}
+
+	if p.Qualifiers == nil {
+		p.Qualifiers = map[string]string{}
+	}
+	p.Qualifiers["checksum"] = strings.Join(append(checksums, digest), ",")
+
+	return nil
+}
+
+// checksumDigest returns the first supported digest in the checksum qualifier, or nil if there isn't one
+func (p *packageURL) checksumDigest() *v1alpha1.Digest {
+	for _, checksum := range strings.Split(p.Qualifiers["checksum"], ",") {
+		if digest, err := normalizeDigestString(checksum); err == nil {
+			return parseArtifactDigest(digest)
+		}
+	}
+
+	return nil
+}
+
+func (p *packageURL) validate() error {
+	switch p.Type {
+	case "maven":
+		if len(p.Namespace) == 0 {
+			return errors.New("maven package urls must have a group id namespace")
+		}
+	}
+
+	if _, ok := purlResourceUriFormatters[p.Type]; ok && p.Version == "" {
+		return fmt.Errorf("%s package urls must have a version", p.Type)
+	}
+
+	return nil
+}
+
+// String returns the canonical form of the package url, with qualifiers sorted by key
+func (p *packageURL) String() string {
+	var b strings.Builder
+	b.WriteString(purlScheme + p.Type)
+
+	for _, segment := range append(append([]string{}, p.Namespace...), p.Name) {
+		b.WriteString("/" + escapePackageURLComponent(segment))
+	}
+
+	if p.Version != "" {
+		b.WriteString("@" + escapePackageURLComponent(p.Version))
+	}
+
+	if len(p.Qualifiers) != 0 {
+		var keys []string
+		for key := range p.Qualifiers {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var qualifiers []string
+		for _, key := range keys {
+			qualifiers = append(qualifiers, key+"="+escapePackageURLComponent(p.Qualifiers[key]))
+		}
+		b.WriteString("?" + strings.Join(qualifiers, "&"))
+	}
+
+	if p.Subpath != "" {
+		var segments []string
+		for _, segment := range strings.Split(p.Subpath, "/") {
+			segments = append(segments, escapePackageURLComponent(segment))
+		}
+		b.WriteString("#" + strings.Join(segments, "/"))
+	}
+
+	return b.String()
+}
+
+func escapePackageURLComponent(component string) string {
+	escaped := strings.ReplaceAll(url.QueryEscape(component), "+", "%20")
+
+	return strings.NewReplacer("%3A", ":", "%2C", ",").Replace(escaped)
+}
+
+// purlResourceUriFormatters convert package urls into the resource uri format Rode uses for that package type
+var purlResourceUriFormatters = map[string]func(p *packageURL) string{
+	"maven": func(p *packageURL) string {
+		return fmt.Sprintf("gav://%s:%s:%s", strings.Join(p.Namespace, "."), p.Name, p.Version)
+	},
+	"npm": func(p *packageURL) string {
+		return fmt.Sprintf("npm://%s:%s", strings.Join(append(append([]string{}, p.Namespace...), p.Name), "/"), p.Version)
+	},
+	"nuget": func(p *packageURL) string {
+		return fmt.Sprintf("nuget://%s:%s", p.Name, p.Version)
+	},
+	"pypi": func(p *packageURL) string {
+		return fmt.Sprintf("pip://%s:%s", p.Name, p.Version)
+	},
+	"deb": func(p *packageURL) string {
+		return fmt.Sprintf("deb://%s:%s:%s:%s", p.Qualifiers["distro"], p.Qualifiers["arch"], p.Name, p.Version)
+	},
+	"rpm": func(p *packageURL) string {
+		return fmt.Sprintf("rpm://%s:%s:%s:%s", p.Qualifiers["distro"], p.Qualifiers["arch"], p.Name, p.Version)
+	},
+}
+
+// packageURLToResourceUri returns the Rode resource uri for a package url. Images pinned to a digest become image
+// references, and generic packages with a sha256 checksum become file uris. Package types Rode doesn't have a resource
+// type for are identified by their canonical package url.
+func packageURLToResourceUri(p *packageURL) (string, error) {
+	if err := p.validate(); err != nil {
+		return "", err
+	}
+
+	if format, ok := purlResourceUriFormatters[p.Type]; ok {
+		return format(p), nil
+	}
+
+	switch p.Type {
+	case "docker", "oci":
+		if digest, err := normalizeDigestString(p.Version); err == nil {
+			repository := p.Qualifiers["repository_url"]
+			if repository == "" && p.Type == "docker" {
+				repository = "docker.io"
+			}
+
+			reference := strings.Join(append(append([]string{}, p.Namespace...), p.Name), "/")
+			if repository != "" {
+				reference = strings.TrimSuffix(repository, "/") + "/" + reference
+			}
+
+			return reference + "@" + digest, nil
+		}
+	case "generic":
+		for _, checksum := range strings.Split(p.Qualifiers["checksum"], ",") {
+			if digest, err := normalizeDigestString(checksum); err == nil && strings.HasPrefix(digest, "sha256:") {
+				return fmt.Sprintf("file://%s:%s", digest, p.Name), nil
+			}
+		}
+	}
+
+	return p.String(), nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/rode/collector-build/proto/v1alpha1"
+)
+
+var _ = Describe("purl", func() {
+	sha256Hex := strings.Repeat("b2", 32)
+
+	Describe("parsePackageURL", func() {
+		It("should parse each component", func() {
+			actual, err := parsePackageURL("pkg:maven/org.apache.commons/commons-lang3@3.12.0?type=jar&classifier=sources#src/main")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual).To(Equal(&packageURL{
+				Type:       "maven",
+				Namespace:  []string{"org.apache.commons"},
+				Name:       "commons-lang3",
+				Version:    "3.12.0",
+				Qualifiers: map[string]string{"type": "jar", "classifier": "sources"},
+				Subpath:    "src/main",
+			}))
+		})
+
+		DescribeTable("canonical form", func(purl, expected string) {
+			actual, err := parsePackageURL(purl)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.String()).To(Equal(expected))
+		},
+			Entry("uppercase scheme and type", "PKG:NPM/left-pad@1.3.0", "pkg:npm/left-pad@1.3.0"),
+			Entry("scoped npm package", "pkg:npm/%40Angular/core@12.0.0", "pkg:npm/%40angular/core@12.0.0"),
+			Entry("unencoded npm scope", "pkg:npm/@angular/core@12.0.0", "pkg:npm/%40angular/core@12.0.0"),
+			Entry("pypi name", "pkg:pypi/Django_Rest@3.12.4", "pkg:pypi/django-rest@3.12.4"),
+			Entry("sorted qualifiers", "pkg:deb/debian/curl@7.50.3-1?distro=jessie&arch=i386", "pkg:deb/debian/curl@7.50.3-1?arch=i386&distro=jessie"),
+			Entry("empty qualifier", "pkg:golang/go.uber.org/zap@v1.16.0?goos=", "pkg:golang/go.uber.org/zap@v1.16.0"),
+			Entry("go module", "pkg:golang/github.com/rode/collector-build@v0.1.0", "pkg:golang/github.com/rode/collector-build@v0.1.0"),
+			Entry("no version", "pkg:github/Rode/Collector-Build", "pkg:github/rode/collector-build"),
+			Entry("leading slashes", "pkg://npm/left-pad@1.3.0", "pkg:npm/left-pad@1.3.0"),
+		)
+
+		DescribeTable("invalid package urls", func(purl string) {
+			_, err := parsePackageURL(purl)
+
+			Expect(err).To(HaveOccurred())
+		},
+			Entry("wrong scheme", "npm/left-pad@1.3.0"),
+			Entry("missing name", "pkg:npm"),
+			Entry("invalid type", "pkg:n%20pm/left-pad@1.3.0"),
+			Entry("empty namespace segment", "pkg:maven//commons-lang3@3.12.0"),
+			Entry("empty version", "pkg:npm/left-pad@"),
+			Entry("invalid qualifier key", "pkg:npm/left-pad@1.3.0?1type=tgz"),
+			Entry("qualifier without a value", "pkg:npm/left-pad@1.3.0?type"),
+			Entry("duplicate qualifier", "pkg:npm/left-pad@1.3.0?type=tgz&type=zip"),
+			Entry("bad escape", "pkg:npm/left%zzpad@1.3.0"),
+		)
+	})
+
+	Describe("packageURLToResourceUri", func() {
+		DescribeTable("resource uris", func(purl, expected string) {
+			p, err := parsePackageURL(purl)
+			Expect(err).NotTo(HaveOccurred())
+
+			actual, err := packageURLToResourceUri(p)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual).To(Equal(expected))
+		},
+			Entry("maven", "pkg:maven/org.apache.commons/commons-lang3@3.12.0", "gav://org.apache.commons:commons-lang3:3.12.0"),
+			Entry("npm", "pkg:npm/left-pad@1.3.0", "npm://left-pad:1.3.0"),
+			Entry("scoped npm", "pkg:npm/%40angular/core@12.0.0", "npm://@angular/core:12.0.0"),
+			Entry("nuget", "pkg:nuget/Newtonsoft.Json@13.0.1", "nuget://Newtonsoft.Json:13.0.1"),
+			Entry("pypi", "pkg:pypi/django@3.2.5", "pip://django:3.2.5"),
+			Entry("deb", "pkg:deb/debian/curl@7.50.3-1?arch=i386&distro=jessie", "deb://jessie:i386:curl:7.50.3-1"),
+			Entry("rpm", "pkg:rpm/fedora/curl@7.50.3-1.fc25?arch=i386", "rpm://:i386:curl:7.50.3-1.fc25"),
+			Entry("docker with digest", "pkg:docker/rode/collector-build@sha256%3A"+sha256Hex+"?repository_url=ghcr.io", "ghcr.io/rode/collector-build@sha256:"+sha256Hex),
+			Entry("docker hub with digest", "pkg:docker/library/alpine@sha256:"+sha256Hex, "docker.io/library/alpine@sha256:"+sha256Hex),
+			Entry("docker with tag", "pkg:docker/library/alpine@3.14", "pkg:docker/library/alpine@3.14"),
+			Entry("generic with checksum", "pkg:generic/collector-build.tgz@0.1.0?checksum=sha1:"+strings.Repeat("a1", 20)+",sha256:"+sha256Hex, "file://sha256:"+sha256Hex+":collector-build.tgz"),
+			Entry("go module", "pkg:golang/github.com/rode/collector-build@v0.1.0", "pkg:golang/github.com/rode/collector-build@v0.1.0"),
+		)
+
+		DescribeTable("package urls that can't be resource uris", func(purl string) {
+			p, err := parsePackageURL(purl)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = packageURLToResourceUri(p)
+
+			Expect(err).To(HaveOccurred())
+		},
+			Entry("maven without a group", "pkg:maven/commons-lang3@3.12.0"),
+			Entry("npm without a version", "pkg:npm/left-pad"),
+		)
+	})
+
+	Describe("normalizeArtifact", func() {
+		It("should identify the artifact by its resource uri and keep the package url as a name", func() {
+			actual, err := normalizeArtifact(&v1alpha1.Artifact{
+				Id:    "pkg:npm/Left-Pad@1.3.0",
+				Names: []string{"left-pad"},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Id).To(Equal("npm://left-pad:1.3.0"))
+			Expect(actual.Names).To(Equal([]string{"left-pad", "pkg:npm/left-pad@1.3.0"}))
+		})
+
+		It("should record a structured digest as a checksum", func() {
+			actual, err := normalizeArtifact(&v1alpha1.Artifact{
+				Id:     "pkg:generic/collector-build.tgz@0.1.0",
+				Digest: &v1alpha1.Digest{Algorithm: "sha256", Hex: sha256Hex},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Id).To(Equal("file://sha256:" + sha256Hex + ":collector-build.tgz"))
+			Expect(actual.Names).To(ConsistOf("pkg:generic/collector-build.tgz@0.1.0?checksum=sha256:" + sha256Hex))
+		})
+
+		It("should keep the digest of a package whose resource uri doesn't include it", func() {
+			actual, err := normalizeArtifact(&v1alpha1.Artifact{
+				Id:     "pkg:maven/org.apache.commons/commons-lang3@3.12.0",
+				Digest: &v1alpha1.Digest{Algorithm: "SHA256", Hex: strings.ToUpper(sha256Hex)},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Id).To(Equal("gav://org.apache.commons:commons-lang3:3.12.0"))
+			Expect(actual.Digest).To(Equal(&v1alpha1.Digest{Algorithm: "sha256", Hex: sha256Hex}))
+			Expect(artifactChecksum(actual)).To(Equal("sha256:" + sha256Hex))
+		})
+
+		It("should take the digest from a checksum qualifier", func() {
+			actual, err := normalizeArtifact(&v1alpha1.Artifact{Id: "pkg:npm/left-pad@1.3.0?checksum=sha256:" + sha256Hex})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Id).To(Equal("npm://left-pad:1.3.0"))
+			Expect(actual.Digest).To(Equal(&v1alpha1.Digest{Algorithm: "sha256", Hex: sha256Hex}))
+		})
+
+		It("should reject a digest that conflicts with the checksum", func() {
+			_, err := normalizeArtifact(&v1alpha1.Artifact{
+				Id:     "pkg:generic/collector-build.tgz@0.1.0?checksum=sha256:" + strings.Repeat("dd", 32),
+				Digest: &v1alpha1.Digest{Algorithm: "sha256", Hex: sha256Hex},
+			})
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
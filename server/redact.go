@@ -0,0 +1,236 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rode/collector-build/config"
+	"github.com/rode/collector-build/proto/v1alpha1"
+	"google.golang.org/protobuf/proto"
+)
+
+const redactedValue = "REDACTED"
+
+// secretQueryParams are query parameters commonly used to pass credentials, such as access tokens and the signatures
+// of pre-signed object storage urls. Parameter names are compared case-insensitively.
+var secretQueryParams = []string{
+	"access_token",
+	"api_key",
+	"apikey",
+	"auth",
+	"auth_token",
+	"client_secret",
+	"id_token",
+	"password",
+	"private_token",
+	"refresh_token",
+	"secret",
+	"sig",
+	"signature",
+	"token",
+	"x-amz-credential",
+	"x-amz-security-token",
+	"x-amz-signature",
+	"x-goog-credential",
+	"x-goog-signature",
+}
+
+// urlRedactor removes credentials from the urls in requests, so that they aren't stored on occurrences or logged
+type urlRedactor struct {
+	queryParams map[string]bool
+	patterns    []*regexp.Regexp
+}
+
+func newURLRedactor(conf *config.Config) *urlRedactor {
+	queryParams := map[string]bool{}
+	for _, param := range append(append([]string{}, secretQueryParams...), conf.RedactQueryParams...) {
+		queryParams[strings.ToLower(param)] = true
+	}
+
+	return &urlRedactor{
+		queryParams: queryParams,
+		patterns:    conf.RedactPatterns,
+	}
+}
+
+// redactURL removes any userinfo from a url, replaces the values of secret query parameters
+// and then replaces anything matching the configured patterns. Values that aren't urls are only matched
+// against the configured patterns.
+func (r *urlRedactor) redactURL(value string) string {
+	if parsed, err := url.Parse(value); err == nil && parsed.Scheme != "" && parsed.Host != "" {
+		query := r.redactQuery(parsed.RawQuery)
+		if parsed.User != nil || query != parsed.RawQuery {
+			parsed.User = nil
+			parsed.RawQuery = query
+			value = parsed.String()
+		}
+	}
+
+	for _, pattern := range r.patterns {
+		value = pattern.ReplaceAllString(value, redactedValue)
+	}
+
+	return value
+}
+
+func (r *urlRedactor) redactQuery(query string) string {
+	if query == "" {
+		return query
+	}
+
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		pieces := strings.SplitN(param, "=", 2)
+		key, err := url.QueryUnescape(pieces[0])
+		if err != nil {
+			key = pieces[0]
+		}
+
+		if len(pieces) == 2 && r.queryParams[strings.ToLower(key)] {
+			params[i] = pieces[0] + "=" + redactedValue
+		}
+	}
+
+	return strings.Join(params, "&")
+}
+
+func (r *urlRedactor) redactArtifact(artifact *v1alpha1.Artifact) {
+	if artifact == nil {
+		return
+	}
+
+	artifact.Id = r.redactURL(artifact.Id)
+	for i, name := range artifact.Names {
+		artifact.Names[i] = r.redactURL(name)
+	}
+}
+
+// redactCreateBuildRequest returns a copy of the request with every url redacted
+func (r *urlRedactor) redactCreateBuildRequest(request *v1alpha1.CreateBuildRequest) *v1alpha1.CreateBuildRequest {
+	redacted := proto.Clone(request).(*v1alpha1.CreateBuildRequest)
+
+	redacted.Repository = r.redactURL(redacted.Repository)
+	redacted.LogsUri = r.redactURL(redacted.LogsUri)
+	redacted.CommitUri = r.redactURL(redacted.CommitUri)
+	for _, artifact := range redacted.Artifacts {
+		r.redactArtifact(artifact)
+	}
+	for _, material := range redacted.Materials {
+		material.Uri = r.redactURL(material.Uri)
+	}
+
+	switch source := redacted.Source.(type) {
+	case *v1alpha1.CreateBuildRequest_Gerrit:
+		source.Gerrit.HostUri = r.redactURL(source.Gerrit.HostUri)
+	case *v1alpha1.CreateBuildRequest_Archive:
+		source.Archive.Uri = r.redactURL(source.Archive.Uri)
+	}
+
+	return redacted
+}
+
+// redactUpdateBuildArtifactsRequest returns a copy of the request with the artifact urls redacted
+func (r *urlRedactor) redactUpdateBuildArtifactsRequest(request *v1alpha1.UpdateBuildArtifactsRequest) *v1alpha1.UpdateBuildArtifactsRequest {
+	redacted := proto.Clone(request).(*v1alpha1.UpdateBuildArtifactsRequest)
+
+	redacted.ExistingArtifactId = r.redactURL(redacted.ExistingArtifactId)
+	r.redactArtifact(redacted.NewArtifact)
+
+	return redacted
+}
+
+// redactRemoveBuildArtifactRequest returns a copy of the request with the artifact url redacted
+func (r *urlRedactor) redactRemoveBuildArtifactRequest(request *v1alpha1.RemoveBuildArtifactRequest) *v1alpha1.RemoveBuildArtifactRequest {
+	redacted := proto.Clone(request).(*v1alpha1.RemoveBuildArtifactRequest)
+	redacted.ArtifactId = r.redactURL(redacted.ArtifactId)
+
+	return redacted
+}
+
+// redactReplaceBuildArtifactsRequest returns a copy of the request with the artifact urls redacted
+func (r *urlRedactor) redactReplaceBuildArtifactsRequest(request *v1alpha1.ReplaceBuildArtifactsRequest) *v1alpha1.ReplaceBuildArtifactsRequest {
+	redacted := proto.Clone(request).(*v1alpha1.ReplaceBuildArtifactsRequest)
+
+	redacted.ExistingArtifactId = r.redactURL(redacted.ExistingArtifactId)
+	for _, artifact := range redacted.Artifacts {
+		r.redactArtifact(artifact)
+	}
+
+	return redacted
+}
+
+// redactListBuildsRequest returns a copy of the request with the repository and artifact urls redacted
+func (r *urlRedactor) redactListBuildsRequest(request *v1alpha1.ListBuildsRequest) *v1alpha1.ListBuildsRequest {
+	redacted := proto.Clone(request).(*v1alpha1.ListBuildsRequest)
+
+	redacted.Repository = r.redactURL(redacted.Repository)
+	redacted.ArtifactId = r.redactURL(redacted.ArtifactId)
+
+	return redacted
+}
+
+// findUnredactedValue returns the path to the first string in a JSON document that redaction would change. Ingested
+// provenance is recorded as it was received, and changing it would break its signature, so a document with
+// credentials in it is rejected rather than stored.
+func (r *urlRedactor) findUnredactedValue(document []byte) (string, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return "", false
+	}
+
+	return r.findUnredactedJsonValue("", value)
+}
+
+func (r *urlRedactor) findUnredactedJsonValue(path string, value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return path, r.redactURL(v) != v
+	case []interface{}:
+		for i, item := range v {
+			if itemPath, ok := r.findUnredactedJsonValue(fmt.Sprintf("%s[%d]", path, i), item); ok {
+				return itemPath, true
+			}
+		}
+	case map[string]interface{}:
+		var keys []string
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+
+			if fieldPath, ok := r.findUnredactedJsonValue(keyPath, v[key]); ok {
+				return fieldPath, true
+			}
+		}
+	}
+
+	return "", false
+}
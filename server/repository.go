@@ -0,0 +1,83 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	// scpLikeRepositoryPattern matches the scp-style syntax understood by Git, e.g. git@github.com:rode/collector-build.git
+	scpLikeRepositoryPattern = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+
+	repositoryDefaultPorts = map[string]string{
+		"git":     "9418",
+		"http":    "80",
+		"https":   "443",
+		"ssh":     "22",
+		"git+ssh": "22",
+		"ssh+git": "22",
+	}
+)
+
+// buildRepositoryResourceUri normalizes a Git repository url into the resource uri Rode uses for Git repositories,
+// so that the same repository is always identified the same way regardless of how it was cloned.
+// HTTP(S), SSH, git and scp-style urls are accepted. Credentials, default ports, trailing slashes and a .git suffix
+// are removed and the host is lowercased, e.g. git@GitHub.com:rode/collector-build.git becomes
+// git://github.com/rode/collector-build
+func buildRepositoryResourceUri(repository string) (string, error) {
+	repository = strings.TrimSpace(repository)
+
+	var host, repositoryPath string
+	if matches := scpLikeRepositoryPattern.FindStringSubmatch(repository); matches != nil && !strings.Contains(repository, "://") {
+		host = matches[1]
+		repositoryPath = matches[2]
+	} else {
+		repositoryURL, err := url.Parse(repository)
+		if err != nil {
+			return "", err
+		}
+
+		scheme := strings.ToLower(repositoryURL.Scheme)
+		defaultPort, ok := repositoryDefaultPorts[scheme]
+		if !ok {
+			return "", fmt.Errorf("unsupported repository url scheme %q", repositoryURL.Scheme)
+		}
+
+		host = repositoryURL.Hostname()
+		if port := repositoryURL.Port(); port != "" && port != defaultPort {
+			host = host + ":" + port
+		}
+		repositoryPath = repositoryURL.Path
+	}
+
+	if host == "" {
+		return "", errors.New("repository url must have a host")
+	}
+
+	repositoryPath = path.Clean("/" + repositoryPath)
+	repositoryPath = strings.TrimSuffix(repositoryPath, ".git")
+	repositoryPath = strings.TrimRight(repositoryPath, "/")
+	if repositoryPath == "" {
+		return "", errors.New("repository url must have a path")
+	}
+
+	return fmt.Sprintf("git://%s%s", strings.ToLower(host), repositoryPath), nil
+}
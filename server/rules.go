@@ -0,0 +1,219 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/rode/collector-build/config"
+	"github.com/rode/collector-build/proto/v1alpha1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ruleFieldValue is a value that a validation rule applies to, along with the path to it in the request
+type ruleFieldValue struct {
+	path  string
+	value string
+}
+
+// ruleFieldValues holds the values in a request for each validation rule field. Fields that aren't part of the
+// request are absent, so rules for them are skipped. The path is used to report a required field that's missing.
+// When allowlistRequired is set, a missing value also violates an allowlist rule, as if the rule were required.
+type ruleFieldValues map[string]struct {
+	path              string
+	values            []ruleFieldValue
+	allowlistRequired bool
+}
+
+func (r ruleFieldValues) add(field, path string, values ...ruleFieldValue) {
+	entry := r[field]
+	entry.path = path
+	entry.values = append(entry.values, values...)
+	r[field] = entry
+}
+
+func (r ruleFieldValues) requireForAllowlist(field string) {
+	entry := r[field]
+	entry.allowlistRequired = true
+	r[field] = entry
+}
+
+func createBuildRequestRuleFieldValues(request *v1alpha1.CreateBuildRequest) ruleFieldValues {
+	values := ruleFieldValues{}
+	single := func(field, path, value string) {
+		values.add(field, path, ruleFieldValue{path: path, value: value})
+	}
+
+	single("repository", "repository", request.Repository)
+	single("commit_id", "commit_id", request.CommitId)
+	single("commit_uri", "commit_uri", request.CommitUri)
+	single("logs_uri", "logs_uri", request.LogsUri)
+	single("provenance_id", "provenance_id", request.ProvenanceId)
+	single("creator", "creator", request.Creator)
+	single("note", "note", request.Note)
+	single("builder_id", "builder.id", request.GetBuilder().GetId())
+
+	hostPath, host := repositoryHost(request)
+	single("repository_host", hostPath, host)
+	values.requireForAllowlist("repository_host")
+
+	addArtifactRuleFieldValues(values, "artifacts", request.Artifacts)
+
+	values.add("material_uri", "materials")
+	for i, material := range request.Materials {
+		path := fmt.Sprintf("materials[%d].uri", i)
+		values.add("material_uri", "materials", ruleFieldValue{path: path, value: material.Uri})
+	}
+
+	return values
+}
+
+// repositoryHost returns the host that a build's source was fetched from, along with the path to the request field it
+// was derived from. Cloud repos don't have a host, and neither do archives without one in their uri, so the host is empty.
+func repositoryHost(request *v1alpha1.CreateBuildRequest) (string, string) {
+	switch source := request.Source.(type) {
+	case *v1alpha1.CreateBuildRequest_Gerrit:
+		resourceUri, err := buildRepositoryResourceUri(strings.TrimRight(source.Gerrit.HostUri, "/") + "/" + source.Gerrit.Project)
+		if err != nil {
+			return "gerrit.host_uri", ""
+		}
+
+		return "gerrit.host_uri", resourceUriHost(resourceUri)
+	case *v1alpha1.CreateBuildRequest_CloudRepo:
+		return "cloud_repo", ""
+	case *v1alpha1.CreateBuildRequest_Archive:
+		if resourceUri, err := buildRepositoryResourceUri(source.Archive.Uri); err == nil {
+			return "archive.uri", resourceUriHost(resourceUri)
+		}
+
+		archiveUrl, err := url.Parse(source.Archive.Uri)
+		if err != nil {
+			return "archive.uri", ""
+		}
+
+		return "archive.uri", strings.ToLower(archiveUrl.Host)
+	}
+
+	resourceUri, err := buildRepositoryResourceUri(request.Repository)
+	if err != nil {
+		return "repository", ""
+	}
+
+	return "repository", resourceUriHost(resourceUri)
+}
+
+// resourceUriHost returns the host of a Git resource uri, git://host/path
+func resourceUriHost(resourceUri string) string {
+	return strings.SplitN(strings.TrimPrefix(resourceUri, "git://"), "/", 2)[0]
+}
+
+func updateBuildArtifactsRequestRuleFieldValues(request *v1alpha1.UpdateBuildArtifactsRequest) ruleFieldValues {
+	values := ruleFieldValues{}
+	if request.NewArtifact != nil {
+		values.add("artifact_id", "new_artifact.id", ruleFieldValue{path: "new_artifact.id", value: request.NewArtifact.Id})
+		values.add("artifact_name", "new_artifact.names")
+		for i, name := range request.NewArtifact.Names {
+			values.add("artifact_name", "new_artifact.names", ruleFieldValue{path: fmt.Sprintf("new_artifact.names[%d]", i), value: name})
+		}
+	}
+
+	return values
+}
+
+func replaceBuildArtifactsRequestRuleFieldValues(request *v1alpha1.ReplaceBuildArtifactsRequest) ruleFieldValues {
+	values := ruleFieldValues{}
+	addArtifactRuleFieldValues(values, "artifacts", request.Artifacts)
+
+	return values
+}
+
+func addArtifactRuleFieldValues(values ruleFieldValues, path string, artifacts []*v1alpha1.Artifact) {
+	values.add("artifact_id", path)
+	values.add("artifact_name", path)
+	for i, artifact := range artifacts {
+		values.add("artifact_id", path, ruleFieldValue{path: fmt.Sprintf("%s[%d].id", path, i), value: artifact.Id})
+		for j, name := range artifact.Names {
+			values.add("artifact_name", path, ruleFieldValue{path: fmt.Sprintf("%s[%d].names[%d]", path, i, j), value: name})
+		}
+	}
+}
+
+// checkValidationRules applies the configured validation rules to the request values
+// and returns every violation, or nil if there are none
+func checkValidationRules(rules []*config.ValidationRule, values ruleFieldValues) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, rule := range rules {
+		field, ok := values[rule.Field]
+		if !ok {
+			continue
+		}
+
+		present := false
+		for _, v := range field.values {
+			if v.value == "" {
+				continue
+			}
+			present = true
+
+			if rule.Pattern != nil && !rule.Pattern.MatchString(v.value) {
+				violations = append(violations, &errdetails.BadRequest_FieldViolation{
+					Field:       v.path,
+					Description: fmt.Sprintf("%s must match %s", rule.Field, rule.Pattern),
+				})
+			}
+
+			if len(rule.Allowlist) != 0 && !containsString(rule.Allowlist, v.value) {
+				violations = append(violations, &errdetails.BadRequest_FieldViolation{
+					Field:       v.path,
+					Description: fmt.Sprintf("%s must be one of: %s", rule.Field, strings.Join(rule.Allowlist, ", ")),
+				})
+			}
+		}
+
+		if present {
+			continue
+		}
+
+		if rule.Required {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.path,
+				Description: fmt.Sprintf("%s is required", rule.Field),
+			})
+		} else if len(rule.Allowlist) != 0 && field.allowlistRequired {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.path,
+				Description: fmt.Sprintf("%s must be one of: %s", rule.Field, strings.Join(rule.Allowlist, ", ")),
+			})
+		}
+	}
+
+	return violations
+}
+
+// validationRulesError builds an InvalidArgument error that lists every violation in its message and as BadRequest details
+func validationRulesError(violations []*errdetails.BadRequest_FieldViolation) error {
+	var messages []string
+	for _, violation := range violations {
+		messages = append(messages, fmt.Sprintf("%s: %s", violation.Field, violation.Description))
+	}
+
+	s := status.Newf(codes.InvalidArgument, "Invalid request: %s", strings.Join(messages, "; "))
+
+	return withDetails(s, &errdetails.BadRequest{FieldViolations: violations}).Err()
+}
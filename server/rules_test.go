@@ -0,0 +1,157 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"regexp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rode/collector-build/config"
+	"github.com/rode/collector-build/proto/v1alpha1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("validation rules", func() {
+	var (
+		rules   []*config.ValidationRule
+		request *v1alpha1.CreateBuildRequest
+	)
+
+	BeforeEach(func() {
+		rules = []*config.ValidationRule{
+			{Field: "repository_host", Allowlist: []string{"github.com"}},
+			{Field: "creator", Required: true, Pattern: regexp.MustCompile(`@example\.com$`)},
+			{Field: "artifact_name", Pattern: regexp.MustCompile(`^harbor\.example\.com/`)},
+			{Field: "builder_id", Required: true},
+		}
+		request = &v1alpha1.CreateBuildRequest{
+			Repository: "git@github.com:rode/collector-build.git",
+			Creator:    "dev@example.com",
+			Artifacts: []*v1alpha1.Artifact{
+				{
+					Id:    "sha256:abc",
+					Names: []string{"harbor.example.com/rode/collector-build:latest"},
+				},
+			},
+			Builder: &v1alpha1.Builder{Id: "https://github.com/actions/runner"},
+		}
+	})
+
+	Describe("checkValidationRules", func() {
+		It("should not return violations for a valid request", func() {
+			Expect(checkValidationRules(rules, createBuildRequestRuleFieldValues(request))).To(BeEmpty())
+		})
+
+		It("should return every violation", func() {
+			request.Repository = "https://gitlab.com/rode/collector-build"
+			request.Creator = ""
+			request.Artifacts[0].Names = append(request.Artifacts[0].Names, "docker.io/rode/collector-build:latest")
+			request.Builder = nil
+
+			violations := checkValidationRules(rules, createBuildRequestRuleFieldValues(request))
+
+			Expect(violations).To(Equal([]*errdetails.BadRequest_FieldViolation{
+				{Field: "repository", Description: "repository_host must be one of: github.com"},
+				{Field: "creator", Description: "creator is required"},
+				{Field: "artifacts[0].names[1]", Description: `artifact_name must match ^harbor\.example\.com/`},
+				{Field: "builder.id", Description: "builder_id is required"},
+			}))
+		})
+
+		It("should take the repository host from a gerrit source", func() {
+			request.Repository = ""
+			request.Source = &v1alpha1.CreateBuildRequest_Gerrit{
+				Gerrit: &v1alpha1.GerritSource{HostUri: "https://review.example.com", Project: "rode/collector-build"},
+			}
+
+			violations := checkValidationRules(rules, createBuildRequestRuleFieldValues(request))
+
+			Expect(violations).To(Equal([]*errdetails.BadRequest_FieldViolation{
+				{Field: "gerrit.host_uri", Description: "repository_host must be one of: github.com"},
+			}))
+		})
+
+		It("should take the repository host from an archive uri", func() {
+			request.Repository = ""
+			request.Source = &v1alpha1.CreateBuildRequest_Archive{
+				Archive: &v1alpha1.ArchiveSource{Uri: "https://github.com/rode/collector-build/archive/v1.0.0.tar.gz"},
+			}
+
+			Expect(checkValidationRules(rules, createBuildRequestRuleFieldValues(request))).To(BeEmpty())
+		})
+
+		It("should treat a source without a host as violating a repository host allowlist", func() {
+			request.Repository = ""
+			request.Source = &v1alpha1.CreateBuildRequest_CloudRepo{
+				CloudRepo: &v1alpha1.CloudRepoSource{ProjectId: "rode", RepoName: "collector-build"},
+			}
+
+			violations := checkValidationRules(rules, createBuildRequestRuleFieldValues(request))
+
+			Expect(violations).To(Equal([]*errdetails.BadRequest_FieldViolation{
+				{Field: "cloud_repo", Description: "repository_host must be one of: github.com"},
+			}))
+		})
+
+		It("should only apply rules for fields in an artifact update", func() {
+			updateRequest := &v1alpha1.UpdateBuildArtifactsRequest{
+				NewArtifact: &v1alpha1.Artifact{
+					Id:    "sha256:abc",
+					Names: []string{"docker.io/rode/collector-build:latest"},
+				},
+			}
+
+			violations := checkValidationRules(rules, updateBuildArtifactsRequestRuleFieldValues(updateRequest))
+
+			Expect(violations).To(Equal([]*errdetails.BadRequest_FieldViolation{
+				{Field: "new_artifact.names[0]", Description: `artifact_name must match ^harbor\.example\.com/`},
+			}))
+		})
+
+		It("should apply artifact rules to each replacement artifact", func() {
+			replaceRequest := &v1alpha1.ReplaceBuildArtifactsRequest{
+				Artifacts: []*v1alpha1.Artifact{
+					{Id: "sha256:abc", Names: []string{"harbor.example.com/rode/collector-build:latest"}},
+					{Id: "sha256:def", Names: []string{"docker.io/rode/collector-build:latest"}},
+				},
+			}
+
+			violations := checkValidationRules(rules, replaceBuildArtifactsRequestRuleFieldValues(replaceRequest))
+
+			Expect(violations).To(Equal([]*errdetails.BadRequest_FieldViolation{
+				{Field: "artifacts[1].names[0]", Description: `artifact_name must match ^harbor\.example\.com/`},
+			}))
+		})
+	})
+
+	Describe("validationRulesError", func() {
+		It("should return an invalid argument error with the violations as details", func() {
+			violations := []*errdetails.BadRequest_FieldViolation{
+				{Field: "creator", Description: "creator is required"},
+				{Field: "builder.id", Description: "builder_id is required"},
+			}
+
+			s := status.Convert(validationRulesError(violations))
+
+			Expect(s.Code()).To(Equal(codes.InvalidArgument))
+			Expect(s.Message()).To(Equal("Invalid request: creator: creator is required; builder.id: builder_id is required"))
+			Expect(s.Details()).To(HaveLen(1))
+			Expect(s.Details()[0].(*errdetails.BadRequest).FieldViolations).To(HaveLen(2))
+		})
+	})
+})
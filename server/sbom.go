@@ -0,0 +1,471 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/rode/collector-build/proto/v1alpha1"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/attestation_go_proto"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/common_go_proto"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/grafeas_go_proto"
+)
+
+const (
+	// sbomNoteId is the id Rode gives the attestation note registered by the collector, which SBOM occurrences reference
+	sbomNoteId = collectorId + "-attestation"
+	sbomNote   = rodeProjectId + "/notes/" + sbomNoteId
+	// sbomOccurrenceIdsBuildOption links a build occurrence to the SBOMs attached to its artifacts, as a comma-separated list
+	sbomOccurrenceIdsBuildOption = "sbom_occurrence_ids"
+	cycloneDxXmlNamespacePrefix  = "http://cyclonedx.org/schema/bom/"
+	spdxDocumentId               = "SPDXRef-DOCUMENT"
+)
+
+var sbomFormatNames = map[v1alpha1.AttachSbomRequest_Format]string{
+	v1alpha1.AttachSbomRequest_CYCLONEDX_JSON: "CycloneDX JSON",
+	v1alpha1.AttachSbomRequest_CYCLONEDX_XML:  "CycloneDX XML",
+	v1alpha1.AttachSbomRequest_SPDX_JSON:      "SPDX JSON",
+	v1alpha1.AttachSbomRequest_SPDX_TAG_VALUE: "SPDX tag-value",
+}
+
+// sbomComponent is a component that an SBOM describes, along with the digests and references that identify it
+type sbomComponent struct {
+	name    string
+	digests map[string]string
+	// versions and package urls, which often hold the digest of an image
+	references []string
+}
+
+type cycloneDxBom struct {
+	XMLName     xml.Name
+	BomFormat   string `json:"bomFormat"`
+	SpecVersion string `json:"specVersion"`
+	Metadata    struct {
+		Component *cycloneDxComponent `json:"component" xml:"component"`
+	} `json:"metadata" xml:"metadata"`
+}
+
+type cycloneDxComponent struct {
+	Name    string          `json:"name" xml:"name"`
+	Version string          `json:"version" xml:"version"`
+	Purl    string          `json:"purl" xml:"purl"`
+	Hashes  []cycloneDxHash `json:"hashes" xml:"hashes>hash"`
+}
+
+type cycloneDxHash struct {
+	Alg     string `json:"alg" xml:"alg,attr"`
+	Content string `json:"content" xml:",chardata"`
+}
+
+type spdxDocument struct {
+	SpdxVersion       string              `json:"spdxVersion"`
+	SpdxId            string              `json:"SPDXID"`
+	DocumentDescribes []string            `json:"documentDescribes"`
+	Packages          []*spdxPackage      `json:"packages"`
+	Relationships     []*spdxRelationship `json:"relationships"`
+}
+
+type spdxPackage struct {
+	SpdxId       string            `json:"SPDXID"`
+	Name         string            `json:"name"`
+	VersionInfo  string            `json:"versionInfo"`
+	Checksums    []spdxChecksum    `json:"checksums"`
+	ExternalRefs []spdxExternalRef `json:"externalRefs"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceLocator string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SpdxElementId      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSpdxElement string `json:"relatedSpdxElement"`
+}
+
+// parseSbom validates the document as an SBOM in the given format, detecting the format when it's unspecified, and
+// returns the components the SBOM describes
+func parseSbom(format v1alpha1.AttachSbomRequest_Format, document []byte) (v1alpha1.AttachSbomRequest_Format, []*sbomComponent, error) {
+	if format == v1alpha1.AttachSbomRequest_FORMAT_UNSPECIFIED {
+		if format = detectSbomFormat(document); format == v1alpha1.AttachSbomRequest_FORMAT_UNSPECIFIED {
+			return format, nil, errors.New("unrecognized SBOM format, expected CycloneDX JSON or XML, or SPDX JSON or tag-value")
+		}
+	}
+
+	var (
+		components []*sbomComponent
+		err        error
+	)
+	switch format {
+	case v1alpha1.AttachSbomRequest_CYCLONEDX_JSON:
+		components, err = parseCycloneDxJson(document)
+	case v1alpha1.AttachSbomRequest_CYCLONEDX_XML:
+		components, err = parseCycloneDxXml(document)
+	case v1alpha1.AttachSbomRequest_SPDX_JSON:
+		components, err = parseSpdxJson(document)
+	case v1alpha1.AttachSbomRequest_SPDX_TAG_VALUE:
+		components, err = parseSpdxTagValue(document)
+	default:
+		return format, nil, fmt.Errorf("unsupported SBOM format %s", format)
+	}
+
+	if err != nil {
+		return format, nil, fmt.Errorf("invalid %s SBOM: %s", sbomFormatNames[format], err)
+	}
+
+	return format, components, nil
+}
+
+func detectSbomFormat(document []byte) v1alpha1.AttachSbomRequest_Format {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(document, []byte("\xef\xbb\xbf")))
+
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		probe := &struct {
+			BomFormat   string `json:"bomFormat"`
+			SpdxVersion string `json:"spdxVersion"`
+		}{}
+		if json.Unmarshal(trimmed, probe) != nil {
+			return v1alpha1.AttachSbomRequest_FORMAT_UNSPECIFIED
+		}
+
+		if probe.BomFormat != "" {
+			return v1alpha1.AttachSbomRequest_CYCLONEDX_JSON
+		}
+
+		if probe.SpdxVersion != "" {
+			return v1alpha1.AttachSbomRequest_SPDX_JSON
+		}
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		return v1alpha1.AttachSbomRequest_CYCLONEDX_XML
+	case bytes.Contains(trimmed, []byte("SPDXVersion:")):
+		return v1alpha1.AttachSbomRequest_SPDX_TAG_VALUE
+	}
+
+	return v1alpha1.AttachSbomRequest_FORMAT_UNSPECIFIED
+}
+
+func parseCycloneDxJson(document []byte) ([]*sbomComponent, error) {
+	bom := &cycloneDxBom{}
+	if err := json.Unmarshal(document, bom); err != nil {
+		return nil, err
+	}
+
+	if bom.BomFormat != "CycloneDX" {
+		return nil, fmt.Errorf("bomFormat is %q, expected CycloneDX", bom.BomFormat)
+	}
+
+	if bom.SpecVersion == "" {
+		return nil, errors.New("specVersion must be specified")
+	}
+
+	return mapCycloneDxComponent(bom.Metadata.Component)
+}
+
+func parseCycloneDxXml(document []byte) ([]*sbomComponent, error) {
+	bom := &cycloneDxBom{}
+	if err := xml.Unmarshal(document, bom); err != nil {
+		return nil, err
+	}
+
+	if bom.XMLName.Local != "bom" || !strings.HasPrefix(bom.XMLName.Space, cycloneDxXmlNamespacePrefix) {
+		return nil, fmt.Errorf("root element must be a bom in the %s namespace", cycloneDxXmlNamespacePrefix+"{version}")
+	}
+
+	return mapCycloneDxComponent(bom.Metadata.Component)
+}
+
+func mapCycloneDxComponent(component *cycloneDxComponent) ([]*sbomComponent, error) {
+	if component == nil {
+		return nil, errors.New("metadata.component must describe the artifact")
+	}
+
+	described := &sbomComponent{
+		name:       component.Name,
+		digests:    map[string]string{},
+		references: []string{component.Version, component.Purl},
+	}
+	for _, hash := range component.Hashes {
+		described.digests[normalizeSbomDigestAlgorithm(hash.Alg)] = strings.TrimSpace(hash.Content)
+	}
+
+	return []*sbomComponent{described}, nil
+}
+
+func parseSpdxJson(document []byte) ([]*sbomComponent, error) {
+	spdx := &spdxDocument{}
+	if err := json.Unmarshal(document, spdx); err != nil {
+		return nil, err
+	}
+
+	return describedSpdxPackages(spdx)
+}
+
+// parseSpdxTagValue reads the tags needed to find the described packages, skipping over multi-line <text> values
+func parseSpdxTagValue(document []byte) ([]*sbomComponent, error) {
+	spdx := &spdxDocument{}
+	var current *spdxPackage
+	inDocumentSection := true
+	inText := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(document))
+	scanner.Buffer(nil, len(document)+1)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		if inText {
+			inText = !strings.Contains(line, "</text>")
+			continue
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		pieces := strings.SplitN(line, ":", 2)
+		if len(pieces) != 2 {
+			return nil, fmt.Errorf("line %d is not a tag: value pair", lineNumber)
+		}
+
+		tag, value := strings.TrimSpace(pieces[0]), strings.TrimSpace(pieces[1])
+		if strings.HasPrefix(value, "<text>") && !strings.Contains(value, "</text>") {
+			inText = true
+			continue
+		}
+
+		switch tag {
+		case "SPDXVersion":
+			spdx.SpdxVersion = value
+		case "PackageName":
+			current = &spdxPackage{Name: value}
+			spdx.Packages = append(spdx.Packages, current)
+			inDocumentSection = false
+		case "FileName", "SnippetSPDXID", "LicenseID":
+			current = nil
+			inDocumentSection = false
+		case "SPDXID":
+			if inDocumentSection {
+				spdx.SpdxId = value
+			} else if current != nil {
+				current.SpdxId = value
+			}
+		case "PackageVersion":
+			if current != nil {
+				current.VersionInfo = value
+			}
+		case "PackageChecksum":
+			checksum := strings.SplitN(value, ":", 2)
+			if current != nil && len(checksum) == 2 {
+				current.Checksums = append(current.Checksums, spdxChecksum{
+					Algorithm:     strings.TrimSpace(checksum[0]),
+					ChecksumValue: strings.TrimSpace(checksum[1]),
+				})
+			}
+		case "ExternalRef":
+			fields := strings.Fields(value)
+			if current != nil && len(fields) == 3 {
+				current.ExternalRefs = append(current.ExternalRefs, spdxExternalRef{ReferenceLocator: fields[2]})
+			}
+		case "Relationship":
+			if fields := strings.Fields(value); len(fields) == 3 {
+				spdx.Relationships = append(spdx.Relationships, &spdxRelationship{
+					SpdxElementId:      fields[0],
+					RelationshipType:   fields[1],
+					RelatedSpdxElement: fields[2],
+				})
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return describedSpdxPackages(spdx)
+}
+
+// describedSpdxPackages returns the packages the document describes, either through documentDescribes or a
+// DESCRIBES relationship from the document
+func describedSpdxPackages(spdx *spdxDocument) ([]*sbomComponent, error) {
+	if !strings.HasPrefix(spdx.SpdxVersion, "SPDX-") {
+		return nil, fmt.Errorf("SPDX version is %q, expected SPDX-{version}", spdx.SpdxVersion)
+	}
+
+	documentId := spdx.SpdxId
+	if documentId == "" {
+		documentId = spdxDocumentId
+	}
+
+	described := map[string]bool{}
+	for _, id := range spdx.DocumentDescribes {
+		described[id] = true
+	}
+
+	for _, relationship := range spdx.Relationships {
+		switch {
+		case relationship.SpdxElementId == documentId && relationship.RelationshipType == "DESCRIBES":
+			described[relationship.RelatedSpdxElement] = true
+		case relationship.RelatedSpdxElement == documentId && relationship.RelationshipType == "DESCRIBED_BY":
+			described[relationship.SpdxElementId] = true
+		}
+	}
+
+	var components []*sbomComponent
+	for _, pkg := range spdx.Packages {
+		if !described[pkg.SpdxId] {
+			continue
+		}
+
+		component := &sbomComponent{
+			name:       pkg.Name,
+			digests:    map[string]string{},
+			references: []string{pkg.VersionInfo},
+		}
+		for _, checksum := range pkg.Checksums {
+			component.digests[normalizeSbomDigestAlgorithm(checksum.Algorithm)] = checksum.ChecksumValue
+		}
+		for _, ref := range pkg.ExternalRefs {
+			component.references = append(component.references, ref.ReferenceLocator)
+		}
+
+		components = append(components, component)
+	}
+
+	if len(components) == 0 {
+		return nil, errors.New("document doesn't describe any packages")
+	}
+
+	return components, nil
+}
+
+// normalizeSbomDigestAlgorithm converts names like SHA-256 and SHA256 to the form used in artifact digests
+func normalizeSbomDigestAlgorithm(algorithm string) string {
+	return strings.ToLower(strings.ReplaceAll(algorithm, "-", ""))
+}
+
+// findSbomComponent returns the first described component with the digest, matched exactly against the component's
+// hashes or a digest in its version or package url, e.g. pkg:oci/app@sha256%3A.... Digests are compared in their
+// normalized algorithm:hex form.
+func findSbomComponent(components []*sbomComponent, digest *v1alpha1.Digest) *sbomComponent {
+	expected, err := normalizeDigest(digest.Algorithm, digest.Hex)
+	if err != nil {
+		return nil
+	}
+
+	for _, component := range components {
+		for algorithm, value := range component.digests {
+			if actual, err := normalizeDigest(algorithm, value); err == nil && actual == expected {
+				return component
+			}
+		}
+
+		for _, reference := range component.references {
+			if referenceDigest(reference) == expected {
+				return component
+			}
+		}
+	}
+
+	return nil
+}
+
+// referenceDigest returns the normalized digest that a version or package url consists of or ends with, or an empty
+// string if it has none. Package url qualifiers and subpaths are ignored.
+func referenceDigest(reference string) string {
+	if unescaped, err := url.PathUnescape(reference); err == nil {
+		reference = unescaped
+	}
+
+	reference = strings.TrimSpace(reference)
+	if i := strings.IndexAny(reference, "?#"); i != -1 {
+		reference = reference[:i]
+	}
+
+	if i := strings.LastIndex(reference, "@"); i != -1 {
+		reference = reference[i+1:]
+	}
+
+	digest, err := normalizeDigestString(reference)
+	if err != nil {
+		return ""
+	}
+
+	return digest
+}
+
+// checkSbomSize rejects documents larger than the configured limit
+func checkSbomSize(size, limit int) error {
+	if size > limit {
+		return newFieldError("document", "SBOM is larger than the %d byte limit", limit)
+	}
+
+	return nil
+}
+
+// mapSbomToOccurrence records the SBOM as an attestation on the artifact, with the document as its payload
+func mapSbomToOccurrence(artifactId string, document []byte) *grafeas_go_proto.Occurrence {
+	return &grafeas_go_proto.Occurrence{
+		Resource: &grafeas_go_proto.Resource{
+			Uri: artifactId,
+		},
+		NoteName: sbomNote,
+		Kind:     common_go_proto.NoteKind_ATTESTATION,
+		Details: &grafeas_go_proto.Occurrence_Attestation{
+			Attestation: &attestation_go_proto.Details{
+				Attestation: &attestation_go_proto.Attestation{
+					Signature: &attestation_go_proto.Attestation_GenericSignedAttestation{
+						GenericSignedAttestation: &attestation_go_proto.GenericSignedAttestation{
+							SerializedPayload: document,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newSbomNote() *grafeas_go_proto.Note {
+	return &grafeas_go_proto.Note{
+		ShortDescription: "Build Collector SBOM",
+		LongDescription:  "SBOMs attached to build artifacts by the Rode build collector",
+		Kind:             common_go_proto.NoteKind_ATTESTATION,
+		Type: &grafeas_go_proto.Note_AttestationAuthority{
+			AttestationAuthority: &attestation_go_proto.Authority{
+				Hint: &attestation_go_proto.Authority_Hint{HumanReadableName: "sbom"},
+			},
+		},
+	}
+}
+
+// parseSbomOccurrenceIds reads the ids of the SBOMs linked to a build occurrence
+func parseSbomOccurrenceIds(buildOptions map[string]string) []string {
+	if buildOptions[sbomOccurrenceIdsBuildOption] == "" {
+		return nil
+	}
+
+	return strings.Split(buildOptions[sbomOccurrenceIdsBuildOption], ",")
+}
@@ -0,0 +1,183 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/rode/collector-build/proto/v1alpha1"
+)
+
+var (
+	sbomDigest = strings.Repeat("9f", 32)
+
+	cycloneDxJsonSbom = `{
+		"bomFormat": "CycloneDX",
+		"specVersion": "1.4",
+		"metadata": {
+			"component": {
+				"type": "application",
+				"name": "collector-build",
+				"version": "v1.0.0",
+				"hashes": [{"alg": "SHA-256", "content": "` + sbomDigest + `"}]
+			}
+		},
+		"components": [{"name": "zap", "version": "1.19.0"}]
+	}`
+
+	cycloneDxXmlSbom = `<?xml version="1.0" encoding="UTF-8"?>
+		<bom xmlns="http://cyclonedx.org/schema/bom/1.4" version="1">
+			<metadata>
+				<component type="container">
+					<name>ghcr.io/rode/collector-build</name>
+					<version>sha256:` + sbomDigest + `</version>
+				</component>
+			</metadata>
+			<components>
+				<component type="library"><name>zap</name></component>
+			</components>
+		</bom>`
+
+	spdxJsonSbom = `{
+		"spdxVersion": "SPDX-2.2",
+		"SPDXID": "SPDXRef-DOCUMENT",
+		"name": "collector-build",
+		"packages": [
+			{"SPDXID": "SPDXRef-zap", "name": "zap", "versionInfo": "1.19.0"},
+			{
+				"SPDXID": "SPDXRef-collector-build",
+				"name": "collector-build",
+				"checksums": [{"algorithm": "SHA256", "checksumValue": "` + sbomDigest + `"}]
+			}
+		],
+		"relationships": [
+			{"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-collector-build"},
+			{"spdxElementId": "SPDXRef-collector-build", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-zap"}
+		]
+	}`
+
+	spdxTagValueSbom = `SPDXVersion: SPDX-2.2
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: collector-build
+DocumentComment: <text>Generated by the release pipeline
+SPDXID: SPDXRef-not-a-package
+</text>
+
+##### Package: ghcr.io/rode/collector-build
+
+PackageName: ghcr.io/rode/collector-build
+SPDXID: SPDXRef-image
+PackageVersion: v1.0.0
+ExternalRef: PACKAGE-MANAGER purl pkg:oci/collector-build@sha256%3A` + sbomDigest + `?repository_url=ghcr.io/rode
+
+##### Package: zap
+
+PackageName: zap
+SPDXID: SPDXRef-zap
+PackageChecksum: SHA256: ` + strings.Repeat("0", 64) + `
+
+Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-image
+Relationship: SPDXRef-image CONTAINS SPDXRef-zap
+`
+)
+
+var _ = Describe("sbom", func() {
+	sbomArtifactDigest := &v1alpha1.Digest{Algorithm: "sha256", Hex: sbomDigest}
+
+	DescribeTable("parseSbom", func(document string, expectedFormat v1alpha1.AttachSbomRequest_Format, expectedName string) {
+		format, components, err := parseSbom(v1alpha1.AttachSbomRequest_FORMAT_UNSPECIFIED, []byte(document))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(format).To(Equal(expectedFormat))
+		Expect(components).To(HaveLen(1))
+
+		component := findSbomComponent(components, sbomArtifactDigest)
+		Expect(component).NotTo(BeNil())
+		Expect(component.name).To(Equal(expectedName))
+	},
+		Entry("CycloneDX JSON", cycloneDxJsonSbom, v1alpha1.AttachSbomRequest_CYCLONEDX_JSON, "collector-build"),
+		Entry("CycloneDX XML", cycloneDxXmlSbom, v1alpha1.AttachSbomRequest_CYCLONEDX_XML, "ghcr.io/rode/collector-build"),
+		Entry("SPDX JSON", spdxJsonSbom, v1alpha1.AttachSbomRequest_SPDX_JSON, "collector-build"),
+		Entry("SPDX tag-value", spdxTagValueSbom, v1alpha1.AttachSbomRequest_SPDX_TAG_VALUE, "ghcr.io/rode/collector-build"),
+	)
+
+	DescribeTable("invalid SBOMs", func(format v1alpha1.AttachSbomRequest_Format, document, expectedError string) {
+		_, _, err := parseSbom(format, []byte(document))
+
+		Expect(err).To(MatchError(ContainSubstring(expectedError)))
+	},
+		Entry("unrecognized", v1alpha1.AttachSbomRequest_FORMAT_UNSPECIFIED, "name,version\nzap,1.19.0", "unrecognized SBOM format"),
+		Entry("unrecognized JSON", v1alpha1.AttachSbomRequest_FORMAT_UNSPECIFIED, `{"name": "zap"}`, "unrecognized SBOM format"),
+		Entry("format doesn't match", v1alpha1.AttachSbomRequest_CYCLONEDX_JSON, spdxJsonSbom, "invalid CycloneDX JSON SBOM"),
+		Entry("CycloneDX without a component", v1alpha1.AttachSbomRequest_FORMAT_UNSPECIFIED, `{"bomFormat": "CycloneDX", "specVersion": "1.4"}`, "metadata.component"),
+		Entry("CycloneDX without a spec version", v1alpha1.AttachSbomRequest_FORMAT_UNSPECIFIED, `{"bomFormat": "CycloneDX", "metadata": {"component": {}}}`, "specVersion"),
+		Entry("XML that isn't CycloneDX", v1alpha1.AttachSbomRequest_FORMAT_UNSPECIFIED, `<project><name>zap</name></project>`, "root element must be a bom"),
+		Entry("SPDX without described packages", v1alpha1.AttachSbomRequest_SPDX_JSON, `{"spdxVersion": "SPDX-2.2", "packages": [{"SPDXID": "SPDXRef-zap"}]}`, "doesn't describe any packages"),
+		Entry("SPDX tag-value with a bad line", v1alpha1.AttachSbomRequest_FORMAT_UNSPECIFIED, "SPDXVersion: SPDX-2.2\nnot a tag", "line 2"),
+	)
+
+	It("should describe packages listed in documentDescribes", func() {
+		document := strings.Replace(spdxJsonSbom, `"name": "collector-build",`, `"documentDescribes": ["SPDXRef-zap"],`, 1)
+
+		_, components, err := parseSbom(v1alpha1.AttachSbomRequest_SPDX_JSON, []byte(document))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(components).To(HaveLen(2))
+	})
+
+	It("should not match a component with a different digest", func() {
+		_, components, err := parseSbom(v1alpha1.AttachSbomRequest_CYCLONEDX_JSON, []byte(cycloneDxJsonSbom))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(findSbomComponent(components, &v1alpha1.Digest{Algorithm: "sha256", Hex: strings.Repeat("1", 64)})).To(BeNil())
+	})
+
+	DescribeTable("matching a component by a digest in its references", func(reference string, expected bool) {
+		components := []*sbomComponent{{name: "collector-build", references: []string{reference}}}
+
+		component := findSbomComponent(components, sbomArtifactDigest)
+
+		if expected {
+			Expect(component).NotTo(BeNil())
+		} else {
+			Expect(component).To(BeNil())
+		}
+	},
+		Entry("version", "sha256:"+sbomDigest, true),
+		Entry("version in upper case", "SHA256:"+strings.ToUpper(sbomDigest), true),
+		Entry("image reference", "ghcr.io/rode/collector-build@sha256:"+sbomDigest, true),
+		Entry("package url", "pkg:oci/collector-build@sha256%3A"+sbomDigest+"?repository_url=ghcr.io/rode", true),
+		Entry("digest with a suffix", "sha256:"+sbomDigest+"0", false),
+		Entry("digest with a prefix", "sha256:0"+sbomDigest, false),
+		Entry("digest in the middle of a version", "1.0.0-sha256:"+sbomDigest+"-rc1", false),
+		Entry("digest in a package url qualifier", "pkg:oci/collector-build@v1?digest=sha256:"+sbomDigest, false),
+		Entry("different algorithm", "sha512:"+sbomDigest+sbomDigest, false),
+	)
+
+	It("should not match a hash that only starts with the digest", func() {
+		components := []*sbomComponent{{name: "collector-build", digests: map[string]string{"sha256": sbomDigest + "00"}}}
+
+		Expect(findSbomComponent(components, sbomArtifactDigest)).To(BeNil())
+	})
+
+	It("should reject documents over the size limit", func() {
+		Expect(checkSbomSize(10, 10)).To(Succeed())
+		Expect(checkSbomSize(11, 10)).To(MatchError("SBOM is larger than the 10 byte limit"))
+	})
+})
@@ -15,235 +15,1495 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"net/url"
+	"io"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/rode/collector-build/config"
 	"github.com/rode/collector-build/proto/v1alpha1"
 	pb "github.com/rode/rode/proto/v1alpha1"
 	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/build_go_proto"
 	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/common_go_proto"
 	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/grafeas_go_proto"
 	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/provenance_go_proto"
-	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/source_go_proto"
 	"go.uber.org/zap"
 	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
-	rodeProjectId                 = "projects/rode"
-	buildCollectorNote            = rodeProjectId + "/notes/build_collector"
-	buildOccurrenceArtifactFilter = `build.provenance.builtArtifacts.nestedFilter(id == "%s")`
+	rodeProjectId                    = "projects/rode"
+	collectorId                      = "build_collector"
+	collectorNoteId                  = collectorId + "-build"
+	collectorNote                    = rodeProjectId + "/notes/" + collectorNoteId
+	batchCreateBuildsChunkSize       = 100
+	artifactUpdateMaxAttempts        = 5
+	buildOccurrenceArtifactFilter    = `build.provenance.builtArtifacts.nestedFilter(%s)`
+	builtArtifactIdFilter            = `id == %s`
+	buildOccurrenceNameFilter        = `name == %s && %s`
+	buildOccurrenceNoteFilter        = `noteName == %s`
+	buildOccurrenceResourceFilter    = `resource.uri.startsWith(%s)`
+	buildOccurrenceCommitFilter      = `build.provenance.sourceProvenance.context.git.revisionId == %s`
+	buildOccurrenceCreatorFilter     = `build.provenance.creator == %s`
+	buildOccurrenceStartFilter       = `build.provenance.startTime %s %s`
+	buildOccurrenceIdempotencyFilter = `%s && build.provenance.buildOptions.` + idempotencyKeyBuildOption + ` == %s`
+	idempotencyKeyBuildOption        = "idempotency_key"
 )
 
 type BuildCollectorServer struct {
-	logger *zap.Logger
-	rode   pb.RodeClient
+	logger   *zap.Logger
+	rode     pb.RodeClient
+	config   *config.Config
+	locks    *occurrenceLocks
+	redactor *urlRedactor
 }
 
-func NewBuildCollectorServer(logger *zap.Logger, rode pb.RodeClient) *BuildCollectorServer {
+func NewBuildCollectorServer(logger *zap.Logger, rode pb.RodeClient, config *config.Config) *BuildCollectorServer {
 	return &BuildCollectorServer{
-		logger,
-		rode,
+		logger:   logger,
+		rode:     rode,
+		config:   config,
+		locks:    newOccurrenceLocks(),
+		redactor: newURLRedactor(config),
+	}
+}
+
+// RegisterCollector ensures that the collector and the notes its build and SBOM occurrences reference exist in Rode.
+// Rode only creates notes in its own project, so notes configured in any other project must already exist.
+func (s *BuildCollectorServer) RegisterCollector(ctx context.Context) error {
+	log := s.logger.Named("RegisterCollector")
+
+	response, err := s.rode.RegisterCollector(ctx, &pb.RegisterCollectorRequest{
+		Id:    collectorId,
+		Notes: []*grafeas_go_proto.Note{newBuildNote(), newSbomNote()},
+	})
+	if err != nil {
+		return fmt.Errorf("error registering collector with Rode: %w", err)
+	}
+
+	for noteId, noteName := range map[string]string{collectorNoteId: collectorNote, sbomNoteId: sbomNote} {
+		note, ok := response.Notes[noteId]
+		if !ok || note.Name != noteName {
+			log.Error("Collector note not registered", zap.String("note", noteName), zap.Any("response", response))
+			return fmt.Errorf("expected Rode to register note %s", noteName)
+		}
+	}
+
+	log.Info("Registered collector", zap.String("note", collectorNote), zap.String("sbomNote", sbomNote))
+
+	if s.config.ProjectId != rodeProjectId {
+		log.Info("Configured notes are outside of the Rode project and must already exist", zap.Strings("notes", s.config.NoteNames()))
+		return nil
+	}
+
+	for _, noteName := range s.config.NoteNames() {
+		if noteName == collectorNote {
+			continue
+		}
+
+		_, err := s.rode.CreateNote(ctx, &pb.CreateNoteRequest{
+			NoteId: strings.TrimPrefix(noteName, rodeProjectId+"/notes/"),
+			Note:   newBuildNote(),
+		})
+		if err != nil && status.Code(err) != codes.AlreadyExists {
+			return fmt.Errorf("error creating note %s in Rode: %w", noteName, err)
+		}
+
+		log.Info("Registered note", zap.String("note", noteName))
+	}
+
+	return nil
+}
+
+func newBuildNote() *grafeas_go_proto.Note {
+	return &grafeas_go_proto.Note{
+		ShortDescription: "Build Collector",
+		LongDescription:  "Build provenance recorded by the Rode build collector",
+		Kind:             common_go_proto.NoteKind_BUILD,
+		Type: &grafeas_go_proto.Note_Build{
+			Build: &build_go_proto.Build{},
+		},
 	}
 }
 
 func (s *BuildCollectorServer) CreateBuild(ctx context.Context, request *v1alpha1.CreateBuildRequest) (*v1alpha1.CreateBuildResponse, error) {
 	log := s.logger.Named("CreateBuild")
+	if s.config.RequireSignedProvenance {
+		log.Info("Rejecting build without signed provenance")
+		return nil, signedProvenanceRequiredError()
+	}
+
+	return s.createBuild(ctx, log, request, nil)
+}
+
+func (s *BuildCollectorServer) IngestProvenance(ctx context.Context, request *v1alpha1.IngestProvenanceRequest) (*v1alpha1.CreateBuildResponse, error) {
+	log := s.logger.Named("IngestProvenance")
+
+	document, err := parseProvenanceDocument(request.Document)
+	if err != nil {
+		log.Info("Invalid provenance document", zap.Error(err))
+		return nil, invalidRequestError(err)
+	}
+
+	if len(s.config.TrustedKeys) != 0 {
+		keyId, err := verifyProvenanceDocument(document, s.config.TrustedKeys)
+		if err != nil {
+			if s.config.RequireSignedProvenance {
+				log.Info("Provenance signature could not be verified", zap.Error(err))
+				return nil, invalidRequestError(err)
+			}
+
+			log.Info("Recording provenance without a verified signature", zap.Error(err))
+		} else {
+			document.keyId = keyId
+		}
+	}
+
+	if path, ok := s.redactor.findUnredactedValue(document.statement); ok {
+		log.Info("Provenance contains credentials", zap.String("path", path))
+		return nil, invalidRequestError(newFieldError(document.field+"."+path, "provenance contains credentials, which must be removed before it's ingested"))
+	}
+
+	createBuildRequest, err := mapStatementToCreateBuildRequest(document)
+	if err != nil {
+		log.Info("Invalid provenance statement", zap.Error(err))
+		return nil, invalidRequestError(err)
+	}
+	createBuildRequest.Note = request.Note
+	createBuildRequest.IdempotencyMode = request.IdempotencyMode
+
+	return s.createBuild(ctx, log, createBuildRequest, document)
+}
+
+// createBuild validates the request and records it as a new build occurrence, unless the build was already recorded.
+// When the build was described by an ingested provenance document, that document is recorded as its provenance.
+func (s *BuildCollectorServer) createBuild(ctx context.Context, log *zap.Logger, request *v1alpha1.CreateBuildRequest, ingested *provenanceDocument) (*v1alpha1.CreateBuildResponse, error) {
+	request = s.redactor.redactCreateBuildRequest(request)
+	log.Debug("Received request", zap.Any("request", request))
+
+	if err := validateCreateBuildRequest(request); err != nil {
+		return nil, invalidRequestError(err)
+	}
+
+	if violations := checkValidationRules(s.config.ValidationRules, createBuildRequestRuleFieldValues(request)); len(violations) != 0 {
+		log.Info("Request violates validation rules", zap.Int("violations", len(violations)))
+		return nil, validationRulesError(violations)
+	}
+
+	buildOccurrence, err := mapRequestToBuildOccurrence(log, s.config, request, ingested)
+	if err != nil {
+		return nil, err
+	}
+
+	if key := getIdempotencyKey(request); key != "" && request.IdempotencyMode != v1alpha1.CreateBuildRequest_ALWAYS_CREATE {
+		unlock := s.locks.lock(idempotencyLockId(buildOccurrence.NoteName, key))
+		defer unlock()
+
+		existingOccurrence, err := s.findBuildOccurrenceByIdempotencyKey(ctx, buildOccurrence.NoteName, key)
+		if err != nil {
+			log.Error("Error occurred when searching for an existing build occurrence", zap.Error(err))
+
+			return nil, rodeError(err, reasonRodeListOccurrencesFailed, "Error finding existing build occurrence in Rode").Err()
+		}
+
+		if existingOccurrence != nil {
+			existingOccurrenceId := extractOccurrenceIdFromName(existingOccurrence.Name)
+			log.Info("Build occurrence already exists", zap.String("idempotencyKey", key), zap.String("buildOccurrenceId", existingOccurrenceId))
+
+			if request.IdempotencyMode == v1alpha1.CreateBuildRequest_FAIL_IF_EXISTS {
+				return nil, status.Errorf(codes.AlreadyExists, "Build occurrence %s already exists for idempotency key: %s", existingOccurrenceId, key)
+			}
+
+			return &v1alpha1.CreateBuildResponse{
+				BuildOccurrenceId: existingOccurrenceId,
+				AlreadyExists:     true,
+			}, nil
+		}
+	}
+
+	buildOccurrences := &pb.BatchCreateOccurrencesRequest{
+		Occurrences: []*grafeas_go_proto.Occurrence{buildOccurrence},
+	}
+
+	log.Debug("Calling BatchCreateOccurrences")
+	response, err := s.rode.BatchCreateOccurrences(ctx, buildOccurrences)
+	if err != nil {
+		log.Error("Error occurred when calling BatchCreateOccurrences", zap.Error(err))
+
+		return nil, rodeError(err, reasonRodeCreateOccurrencesFailed, "Error creating occurrences in Rode").Err()
+	}
+
+	if len(response.Occurrences) != 1 {
+		log.Warn("Did not get expected occurrences from Rode", zap.Any("response", response))
+		return nil, internalRodeError(reasonRodeMissingOccurrenceData, "Occurrence data not returned from Rode").Err()
+	}
+
+	newOccurrence := response.Occurrences[0]
+
+	return &v1alpha1.CreateBuildResponse{
+		BuildOccurrenceId: extractOccurrenceIdFromName(newOccurrence.Name),
+	}, nil
+}
+
+func (s *BuildCollectorServer) BatchCreateBuilds(ctx context.Context, request *v1alpha1.BatchCreateBuildsRequest) (*v1alpha1.BatchCreateBuildsResponse, error) {
+	log := s.logger.Named("BatchCreateBuilds").With(zap.Int("count", len(request.Builds)))
+	log.Debug("Received request")
+
+	if s.config.RequireSignedProvenance {
+		log.Info("Rejecting builds without signed provenance")
+		return nil, signedProvenanceRequiredError()
+	}
+
+	if len(request.Builds) == 0 {
+		return nil, invalidRequestError(newFieldError("builds", "no builds specified"))
+	}
+
+	results := make([]*v1alpha1.BatchCreateBuildResult, len(request.Builds))
+	var (
+		pendingIndexes     []int
+		pendingOccurrences []*grafeas_go_proto.Occurrence
+		idempotencyLockIds = map[int]string{}
+	)
+	for i, build := range request.Builds {
+		results[i] = &v1alpha1.BatchCreateBuildResult{}
+		build = s.redactor.redactCreateBuildRequest(build)
+
+		if err := validateCreateBuildRequest(build); err != nil {
+			results[i].Error = status.Convert(invalidRequestError(err)).Proto()
+			continue
+		}
+
+		if violations := checkValidationRules(s.config.ValidationRules, createBuildRequestRuleFieldValues(build)); len(violations) != 0 {
+			results[i].Error = status.Convert(validationRulesError(violations)).Proto()
+			continue
+		}
+
+		buildOccurrence, err := mapRequestToBuildOccurrence(log, s.config, build, nil)
+		if err != nil {
+			results[i].Error = status.Convert(err).Proto()
+			continue
+		}
+
+		if key := getIdempotencyKey(build); key != "" && build.IdempotencyMode != v1alpha1.CreateBuildRequest_ALWAYS_CREATE {
+			idempotencyLockIds[i] = idempotencyLockId(buildOccurrence.NoteName, key)
+		}
+
+		pendingIndexes = append(pendingIndexes, i)
+		pendingOccurrences = append(pendingOccurrences, buildOccurrence)
+	}
+
+	lockIds := make([]string, 0, len(idempotencyLockIds))
+	for _, lockId := range idempotencyLockIds {
+		lockIds = append(lockIds, lockId)
+	}
+	unlock := s.locks.lockAll(lockIds)
+	defer unlock()
+
+	// builds that were already recorded, in Rode or earlier in this batch, aren't created again
+	var (
+		createIndexes     []int
+		createOccurrences []*grafeas_go_proto.Occurrence
+		recordedIds       = map[string]string{}
+		firstIndexes      = map[string]int{}
+		duplicateIndexes  []int
+	)
+	for j, i := range pendingIndexes {
+		occurrence := pendingOccurrences[j]
+		lockId, ok := idempotencyLockIds[i]
+		if !ok {
+			createIndexes = append(createIndexes, i)
+			createOccurrences = append(createOccurrences, occurrence)
+			continue
+		}
+
+		if _, ok := firstIndexes[lockId]; ok {
+			duplicateIndexes = append(duplicateIndexes, i)
+			continue
+		}
+		firstIndexes[lockId] = i
+
+		key := getIdempotencyKey(request.Builds[i])
+		existingOccurrence, err := s.findBuildOccurrenceByIdempotencyKey(ctx, occurrence.NoteName, key)
+		if err != nil {
+			log.Error("Error occurred when searching for an existing build occurrence", zap.Error(err))
+			results[i].Error = rodeError(err, reasonRodeListOccurrencesFailed, "Error finding existing build occurrence in Rode").Proto()
+			continue
+		}
+
+		if existingOccurrence != nil {
+			recordedIds[lockId] = extractOccurrenceIdFromName(existingOccurrence.Name)
+			results[i] = existingBuildResult(request.Builds[i], recordedIds[lockId], key)
+			continue
+		}
+
+		createIndexes = append(createIndexes, i)
+		createOccurrences = append(createOccurrences, occurrence)
+	}
+	pendingIndexes, pendingOccurrences = createIndexes, createOccurrences
+
+	for _, chunk := range chunkOccurrences(pendingOccurrences, batchCreateBuildsChunkSize, s.config.RodeMaxMessageSize) {
+		start, end := chunk[0], chunk[1]
+		chunkIndexes := pendingIndexes[start:end]
+
+		log.Debug("Calling BatchCreateOccurrences", zap.Int("chunkStart", start), zap.Int("chunkEnd", end))
+		response, err := s.rode.BatchCreateOccurrences(ctx, &pb.BatchCreateOccurrencesRequest{
+			Occurrences: pendingOccurrences[start:end],
+		})
+		if err != nil {
+			log.Error("Error occurred when calling BatchCreateOccurrences", zap.Error(err))
+			setBatchCreateBuildErrors(results, chunkIndexes, rodeError(err, reasonRodeCreateOccurrencesFailed, "Error creating occurrences in Rode"))
+			continue
+		}
+
+		if len(response.Occurrences) != len(chunkIndexes) {
+			log.Warn("Did not get expected occurrences from Rode", zap.Any("response", response))
+			setBatchCreateBuildErrors(results, chunkIndexes, internalRodeError(reasonRodeMissingOccurrenceData, "Occurrence data not returned from Rode"))
+			continue
+		}
+
+		for i, occurrence := range response.Occurrences {
+			results[chunkIndexes[i]].BuildOccurrenceId = extractOccurrenceIdFromName(occurrence.Name)
+		}
+	}
+
+	for _, i := range duplicateIndexes {
+		lockId := idempotencyLockIds[i]
+		first := results[firstIndexes[lockId]]
+		if first.Error == nil && !first.AlreadyExists {
+			recordedIds[lockId] = first.BuildOccurrenceId
+		}
+
+		if existingOccurrenceId, ok := recordedIds[lockId]; ok {
+			results[i] = existingBuildResult(request.Builds[i], existingOccurrenceId, getIdempotencyKey(request.Builds[i]))
+		} else {
+			results[i].Error = first.Error
+		}
+	}
+
+	return &v1alpha1.BatchCreateBuildsResponse{
+		Results: results,
+	}, nil
+}
+
+// existingBuildResult reports a batched build that was already recorded with the same idempotency key,
+// according to the build's idempotency mode.
+func existingBuildResult(request *v1alpha1.CreateBuildRequest, existingOccurrenceId, key string) *v1alpha1.BatchCreateBuildResult {
+	if request.IdempotencyMode == v1alpha1.CreateBuildRequest_FAIL_IF_EXISTS {
+		return &v1alpha1.BatchCreateBuildResult{
+			Error: status.Newf(codes.AlreadyExists, "Build occurrence %s already exists for idempotency key: %s", existingOccurrenceId, key).Proto(),
+		}
+	}
+
+	return &v1alpha1.BatchCreateBuildResult{
+		BuildOccurrenceId: existingOccurrenceId,
+		AlreadyExists:     true,
+	}
+}
+
+// idempotencyLockId identifies the lock held while looking up and creating a build with an idempotency key.
+// Occurrence ids never contain a slash, so these can't collide with the locks held for artifact updates.
+func idempotencyLockId(noteName, key string) string {
+	return fmt.Sprintf("idempotency/%s/%s", noteName, key)
+}
+
+func (s *BuildCollectorServer) findBuildOccurrenceByIdempotencyKey(ctx context.Context, noteName, key string) (*grafeas_go_proto.Occurrence, error) {
+	response, err := s.rode.ListOccurrences(ctx, &pb.ListOccurrencesRequest{
+		Filter: fmt.Sprintf(buildOccurrenceIdempotencyFilter, s.idempotencyNotesFilter(noteName), strconv.Quote(key)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := response.GetOccurrences()
+	if len(occurrences) == 0 {
+		return nil, nil
+	}
+
+	sortOccurrencesByCreateTime(occurrences)
+
+	return occurrences[0], nil
+}
+
+// getIdempotencyKey returns the key used to de-duplicate build submissions. When the client doesn't supply one,
+// the provenance id and commit are used instead; without a provenance id there's nothing stable to key on.
+func getIdempotencyKey(request *v1alpha1.CreateBuildRequest) string {
+	if request.IdempotencyKey != "" {
+		return request.IdempotencyKey
+	}
+
+	if request.ProvenanceId == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s@%s", request.ProvenanceId, request.CommitId)
+}
+
+// chunkOccurrences splits occurrences into the [start, end) ranges that are sent to Rode in each BatchCreateOccurrences
+// request. A chunk holds at most maxCount occurrences, and is no larger than maxSize bytes, unless a single occurrence
+// is larger than that on its own.
+func chunkOccurrences(occurrences []*grafeas_go_proto.Occurrence, maxCount, maxSize int) [][2]int {
+	var chunks [][2]int
+	start, size := 0, 0
+	for i, occurrence := range occurrences {
+		occurrenceSize := proto.Size(&pb.BatchCreateOccurrencesRequest{Occurrences: []*grafeas_go_proto.Occurrence{occurrence}})
+		if i > start && (i-start == maxCount || size+occurrenceSize > maxSize) {
+			chunks = append(chunks, [2]int{start, i})
+			start, size = i, 0
+		}
+		size += occurrenceSize
+	}
+
+	if start < len(occurrences) {
+		chunks = append(chunks, [2]int{start, len(occurrences)})
+	}
+
+	return chunks
+}
+
+func setBatchCreateBuildErrors(results []*v1alpha1.BatchCreateBuildResult, indexes []int, err *status.Status) {
+	for _, i := range indexes {
+		results[i].Error = err.Proto()
+	}
+}
+
+func (s *BuildCollectorServer) UpdateBuildArtifacts(ctx context.Context, request *v1alpha1.UpdateBuildArtifactsRequest) (*v1alpha1.UpdateBuildArtifactsResponse, error) {
+	request = s.redactor.redactUpdateBuildArtifactsRequest(request)
+	log := s.logger.Named("UpdateBuildArtifacts").With(zap.String("existingArtifact", request.ExistingArtifactId), zap.Any("newArtifact", request.NewArtifact))
+	log.Debug("Received request")
+
+	if s.config.RequireSignedProvenance {
+		log.Info("Rejecting artifact changes without signed provenance")
+		return nil, signedArtifactsRequiredError()
+	}
+
+	if err := validateUpdateBuildArtifactsRequest(request); err != nil {
+		return nil, invalidRequestError(err)
+	}
+
+	if violations := checkValidationRules(s.config.ValidationRules, updateBuildArtifactsRequestRuleFieldValues(request)); len(violations) != 0 {
+		log.Info("Request violates validation rules", zap.Int("violations", len(violations)))
+		return nil, validationRulesError(violations)
+	}
+
+	existingArtifactIds, err := artifactLookupIds(request.ExistingArtifactId)
+	if err != nil {
+		return nil, invalidRequestError(fieldErrorFrom("existing_artifact_id", err))
+	}
+
+	newArtifact, err := normalizeArtifact(request.NewArtifact)
+	if err != nil {
+		return nil, invalidRequestError(fieldErrorFrom("new_artifact", err))
+	}
+
+	occurrence, err := s.findBuildOccurrenceForUpdate(ctx, log, existingArtifactIds, request.BuildOccurrenceId)
+	if err != nil {
+		return nil, err
+	}
+
+	previousArtifacts, res, err := s.modifyBuiltArtifacts(ctx, log, extractOccurrenceIdFromName(occurrence.Name), func(builtArtifacts []*provenance_go_proto.Artifact) ([]*provenance_go_proto.Artifact, error) {
+		merged, _ := mergeArtifact(builtArtifacts, newArtifact)
+
+		return merged, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, artifactStatus := mergeArtifact(previousArtifacts, newArtifact)
+	if artifactStatus == v1alpha1.UpdateBuildArtifactsResponse_ALREADY_PRESENT {
+		log.Info("Artifact already present on build occurrence, skipped update")
+	}
+
+	return &v1alpha1.UpdateBuildArtifactsResponse{
+		BuildOccurrenceId: extractOccurrenceIdFromName(res.Name),
+		ArtifactStatus:    artifactStatus,
+	}, nil
+}
+
+func (s *BuildCollectorServer) RemoveBuildArtifact(ctx context.Context, request *v1alpha1.RemoveBuildArtifactRequest) (*v1alpha1.RemoveBuildArtifactResponse, error) {
+	request = s.redactor.redactRemoveBuildArtifactRequest(request)
+	log := s.logger.Named("RemoveBuildArtifact").With(zap.String("artifact", request.ArtifactId), zap.String("buildOccurrenceId", request.BuildOccurrenceId))
+	log.Debug("Received request")
+
+	if s.config.RequireSignedProvenance {
+		log.Info("Rejecting artifact changes without signed provenance")
+		return nil, signedArtifactsRequiredError()
+	}
+
+	if len(request.ArtifactId) == 0 {
+		return nil, invalidRequestError(newFieldError("artifact_id", "artifact must be specified"))
+	}
+
+	artifactIds, err := artifactLookupIds(request.ArtifactId)
+	if err != nil {
+		return nil, invalidRequestError(fieldErrorFrom("artifact_id", err))
+	}
+
+	occurrence, err := s.findBuildOccurrenceForUpdate(ctx, log, artifactIds, request.BuildOccurrenceId)
+	if err != nil {
+		return nil, err
+	}
+	occurrenceId := extractOccurrenceIdFromName(occurrence.Name)
+
+	_, res, err := s.modifyBuiltArtifacts(ctx, log, occurrenceId, func(builtArtifacts []*provenance_go_proto.Artifact) ([]*provenance_go_proto.Artifact, error) {
+		var remainingArtifacts []*provenance_go_proto.Artifact
+		for _, artifact := range builtArtifacts {
+			if !containsString(artifactIds, artifact.Id) {
+				remainingArtifacts = append(remainingArtifacts, artifact)
+			}
+		}
+
+		if len(remainingArtifacts) == 0 {
+			log.Error("Refusing to remove the only artifact from build occurrence")
+			return nil, status.Errorf(codes.FailedPrecondition, "Cannot remove the only artifact from build occurrence: %s", occurrenceId)
+		}
+
+		return remainingArtifacts, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1alpha1.RemoveBuildArtifactResponse{
+		BuildOccurrenceId: extractOccurrenceIdFromName(res.Name),
+	}, nil
+}
+
+func (s *BuildCollectorServer) ReplaceBuildArtifacts(ctx context.Context, request *v1alpha1.ReplaceBuildArtifactsRequest) (*v1alpha1.ReplaceBuildArtifactsResponse, error) {
+	request = s.redactor.redactReplaceBuildArtifactsRequest(request)
+	log := s.logger.Named("ReplaceBuildArtifacts").With(zap.String("existingArtifact", request.ExistingArtifactId), zap.String("buildOccurrenceId", request.BuildOccurrenceId))
+	log.Debug("Received request", zap.Any("artifacts", request.Artifacts))
+
+	if s.config.RequireSignedProvenance {
+		log.Info("Rejecting artifact changes without signed provenance")
+		return nil, signedArtifactsRequiredError()
+	}
+
+	if err := validateReplaceBuildArtifactsRequest(request); err != nil {
+		return nil, invalidRequestError(err)
+	}
+
+	if violations := checkValidationRules(s.config.ValidationRules, replaceBuildArtifactsRequestRuleFieldValues(request)); len(violations) != 0 {
+		log.Info("Request violates validation rules", zap.Int("violations", len(violations)))
+		return nil, validationRulesError(violations)
+	}
+
+	existingArtifactIds, err := artifactLookupIds(request.ExistingArtifactId)
+	if err != nil {
+		return nil, invalidRequestError(fieldErrorFrom("existing_artifact_id", err))
+	}
+
+	var artifacts []*v1alpha1.Artifact
+	for i, artifact := range request.Artifacts {
+		normalized, err := normalizeArtifact(artifact)
+		if err != nil {
+			return nil, invalidRequestError(fieldErrorFrom(fmt.Sprintf("artifacts[%d]", i), err))
+		}
+		artifacts = append(artifacts, normalized)
+	}
+
+	occurrence, err := s.findBuildOccurrenceForUpdate(ctx, log, existingArtifactIds, request.BuildOccurrenceId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, res, err := s.modifyBuiltArtifacts(ctx, log, extractOccurrenceIdFromName(occurrence.Name), func(_ []*provenance_go_proto.Artifact) ([]*provenance_go_proto.Artifact, error) {
+		var builtArtifacts []*provenance_go_proto.Artifact
+		for _, artifact := range artifacts {
+			builtArtifacts, _ = mergeArtifact(builtArtifacts, artifact)
+		}
+
+		return builtArtifacts, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1alpha1.ReplaceBuildArtifactsResponse{
+		BuildOccurrenceId: extractOccurrenceIdFromName(res.Name),
+	}, nil
+}
+
+// modifyBuiltArtifacts performs a read-modify-write of the occurrence's built artifacts. Writes to the same occurrence
+// are serialized within the collector, but Rode has no conditional update, so after writing the occurrence is read back
+// to confirm the modification survived any concurrent writer. If it didn't, the modification is re-applied to the
+// latest artifacts, up to artifactUpdateMaxAttempts times. modify must be safe to apply more than once.
+// The artifacts as they were before the successful write are returned alongside the updated occurrence.
+func (s *BuildCollectorServer) modifyBuiltArtifacts(ctx context.Context, log *zap.Logger, occurrenceId string, modify func([]*provenance_go_proto.Artifact) ([]*provenance_go_proto.Artifact, error)) ([]*provenance_go_proto.Artifact, *grafeas_go_proto.Occurrence, error) {
+	unlock := s.locks.lock(occurrenceId)
+	defer unlock()
+
+	for attempt := 1; attempt <= artifactUpdateMaxAttempts; attempt++ {
+		occurrence, err := s.getBuildOccurrence(ctx, log, occurrenceId)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		currentArtifacts := occurrence.GetBuild().Provenance.BuiltArtifacts
+		desiredArtifacts, err := modify(cloneArtifacts(currentArtifacts))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if artifactsEqual(currentArtifacts, desiredArtifacts) {
+			return currentArtifacts, occurrence, nil
+		}
+
+		occurrence.GetBuild().Provenance.BuiltArtifacts = desiredArtifacts
+		if err := setSlsaStatement(s.config, occurrence); err != nil {
+			log.Error("Error generating SLSA provenance", zap.Error(err))
+			return nil, nil, status.Errorf(codes.Internal, "Error generating SLSA provenance: %s", err)
+		}
+
+		if err := signBuildOccurrence(s.config, occurrence); err != nil {
+			log.Error("Error signing build occurrence", zap.Error(err))
+			return nil, nil, status.Errorf(codes.Internal, "Error signing build occurrence: %s", err)
+		}
+
+		res, err := s.updateBuiltArtifacts(ctx, log, occurrence)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		persisted, err := s.getBuildOccurrence(ctx, log, occurrenceId)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		persistedArtifacts := persisted.GetBuild().Provenance.BuiltArtifacts
+		if expectedArtifacts, err := modify(cloneArtifacts(persistedArtifacts)); err == nil && artifactsEqual(persistedArtifacts, expectedArtifacts) {
+			return currentArtifacts, res, nil
+		}
+
+		log.Warn("Build occurrence artifacts were modified concurrently, retrying", zap.Int("attempt", attempt))
+	}
+
+	log.Error("Unable to update build occurrence artifacts without conflict")
+
+	return nil, nil, status.Errorf(codes.Aborted, "Build occurrence %s was modified concurrently, gave up after %d attempts", occurrenceId, artifactUpdateMaxAttempts)
+}
+
+// updateBuiltArtifacts writes the built artifacts of the occurrence back to Rode, leaving the rest of the occurrence
+// unchanged apart from the SLSA provenance, whose subjects are the artifacts, and the signature over both
+func (s *BuildCollectorServer) updateBuiltArtifacts(ctx context.Context, log *zap.Logger, occurrence *grafeas_go_proto.Occurrence) (*grafeas_go_proto.Occurrence, error) {
+	paths := []string{"details.build.provenance.built_artifacts"}
+	if slsaEnabled(s.config) && !isIngestedProvenance(occurrence) {
+		paths = append(paths, "details.build.provenance_bytes")
+	}
+
+	if s.config.SigningKey != nil {
+		paths = append(paths, "details.build.provenance.build_options")
+	}
+
+	res, err := s.rode.UpdateOccurrence(ctx, &pb.UpdateOccurrenceRequest{
+		Id:         extractOccurrenceIdFromName(occurrence.Name),
+		Occurrence: occurrence,
+		UpdateMask: &field_mask.FieldMask{
+			Paths: paths,
+		}})
+
+	if err != nil {
+		log.Error("Error calling UpdateOccurrence", zap.Error(err))
+
+		return nil, rodeError(err, reasonRodeUpdateOccurrenceFailed, "Error updating existing artifact in Rode").Err()
+	}
+
+	log.Debug("UpdateOccurrence response", zap.Any("response", res))
+
+	return res, nil
+}
+
+func cloneArtifacts(artifacts []*provenance_go_proto.Artifact) []*provenance_go_proto.Artifact {
+	var clones []*provenance_go_proto.Artifact
+	for _, artifact := range artifacts {
+		clones = append(clones, &provenance_go_proto.Artifact{
+			Checksum: artifact.Checksum,
+			Id:       artifact.Id,
+			Names:    append([]string(nil), artifact.Names...),
+		})
+	}
+
+	return clones
+}
+
+func artifactsEqual(left, right []*provenance_go_proto.Artifact) bool {
+	if len(left) != len(right) {
+		return false
+	}
+
+	for i := range left {
+		if left[i].Checksum != right[i].Checksum || left[i].Id != right[i].Id || len(left[i].Names) != len(right[i].Names) {
+			return false
+		}
+
+		for j := range left[i].Names {
+			if left[i].Names[j] != right[i].Names[j] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// mergeArtifact adds newArtifact to the built artifacts, merging its names into an existing artifact with the same id
+// rather than adding a duplicate entry.
+func mergeArtifact(builtArtifacts []*provenance_go_proto.Artifact, newArtifact *v1alpha1.Artifact) ([]*provenance_go_proto.Artifact, v1alpha1.UpdateBuildArtifactsResponse_ArtifactStatus) {
+	for _, artifact := range builtArtifacts {
+		if artifact.Id != newArtifact.Id {
+			continue
+		}
+
+		existingNames := map[string]bool{}
+		for _, name := range artifact.Names {
+			existingNames[name] = true
+		}
+
+		artifactStatus := v1alpha1.UpdateBuildArtifactsResponse_ALREADY_PRESENT
+		for _, name := range newArtifact.Names {
+			if existingNames[name] {
+				continue
+			}
+
+			existingNames[name] = true
+			artifact.Names = append(artifact.Names, name)
+			artifactStatus = v1alpha1.UpdateBuildArtifactsResponse_MERGED
+		}
+
+		if artifact.Checksum == "" {
+			artifact.Checksum = artifactChecksum(newArtifact)
+		}
+
+		return builtArtifacts, artifactStatus
+	}
+
+	return append(builtArtifacts, &provenance_go_proto.Artifact{
+		Id:       newArtifact.Id,
+		Names:    newArtifact.Names,
+		Checksum: artifactChecksum(newArtifact),
+	}), v1alpha1.UpdateBuildArtifactsResponse_ADDED
+}
+
+// findBuildOccurrenceForUpdate locates the build occurrence that an artifact mutation should apply to, either
+// directly by id or by searching for the build that produced the existing artifact, under any of its lookup ids.
+func (s *BuildCollectorServer) findBuildOccurrenceForUpdate(ctx context.Context, log *zap.Logger, existingArtifactIds []string, buildOccurrenceId string) (*grafeas_go_proto.Occurrence, error) {
+	existingArtifactId := existingArtifactIds[0]
+	if buildOccurrenceId != "" {
+		occurrence, err := s.getBuildOccurrence(ctx, log, buildOccurrenceId)
+		if err != nil {
+			return nil, err
+		}
+
+		if existingArtifactId != "" && !buildOccurrenceHasArtifact(occurrence, existingArtifactIds...) {
+			log.Error("Build occurrence does not contain the existing artifact")
+			return nil, status.Errorf(codes.FailedPrecondition, "Build occurrence %s does not contain artifact: %s", buildOccurrenceId, existingArtifactId)
+		}
+
+		return occurrence, nil
+	}
+
+	response, err := s.rode.ListOccurrences(ctx, &pb.ListOccurrencesRequest{Filter: builtArtifactsFilter(existingArtifactIds)})
+	if err != nil {
+		log.Error("Error occurred when calling ListOccurrences", zap.Error(err))
+
+		return nil, rodeError(err, reasonRodeListOccurrencesFailed, "Error finding existing artifact in Rode").Err()
+	}
+	log.Debug("ListOccurrences response", zap.Any("response", response))
+
+	if len(response.Occurrences) == 0 {
+		log.Error("No occurrence found for artifact")
+		return nil, notFoundError(resourceTypeArtifact, existingArtifactId, "No occurrence found for artifact: %s", existingArtifactId)
+	}
+
+	if len(response.Occurrences) > 1 {
+		var occurrenceIds []string
+		for _, occurrence := range response.Occurrences {
+			occurrenceIds = append(occurrenceIds, extractOccurrenceIdFromName(occurrence.Name))
+		}
+
+		if s.config.StrictArtifactMatching {
+			log.Error("More than one occurrence found for artifact", zap.Strings("occurrenceIds", occurrenceIds))
+			return nil, status.Errorf(codes.FailedPrecondition, "More than one occurrence found for artifact %s, specify a build occurrence id: %s", existingArtifactId, strings.Join(occurrenceIds, ", "))
+		}
+
+		log.Warn("More than one occurrence found for artifact, taking earliest", zap.Strings("occurrenceIds", occurrenceIds))
+	}
 
-	log.Debug("Received request", zap.Any("request", request))
+	sortOccurrencesByCreateTime(response.Occurrences)
 
-	if err := validateCreateBuildRequest(request); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "Invalid request: %s", err)
+	return response.Occurrences[0], nil
+}
+
+// builtArtifactsFilter matches build occurrences with a built artifact that has any of the ids
+func builtArtifactsFilter(artifactIds []string) string {
+	var filters []string
+	for _, artifactId := range artifactIds {
+		filters = append(filters, fmt.Sprintf(builtArtifactIdFilter, strconv.Quote(artifactId)))
+	}
+
+	return fmt.Sprintf(buildOccurrenceArtifactFilter, strings.Join(filters, " || "))
+}
+
+func buildOccurrenceHasArtifact(occurrence *grafeas_go_proto.Occurrence, artifactIds ...string) bool {
+	return findBuiltArtifact(occurrence, artifactIds...) != nil
+}
+
+// findBuiltArtifact returns the occurrence's built artifact with any of the ids, or nil if there isn't one
+func findBuiltArtifact(occurrence *grafeas_go_proto.Occurrence, artifactIds ...string) *provenance_go_proto.Artifact {
+	for _, artifact := range occurrence.GetBuild().GetProvenance().GetBuiltArtifacts() {
+		if containsString(artifactIds, artifact.Id) {
+			return artifact
+		}
+	}
+
+	return nil
+}
+
+func (s *BuildCollectorServer) GetBuild(ctx context.Context, request *v1alpha1.GetBuildRequest) (*v1alpha1.Build, error) {
+	log := s.logger.Named("GetBuild").With(zap.String("id", request.Id))
+	log.Debug("Received request")
+
+	if len(request.Id) == 0 {
+		return nil, invalidRequestError(newFieldError("id", "build occurrence id must be specified"))
 	}
 
-	buildOccurrence, err := mapRequestToBuildOccurrence(log, request)
+	occurrence, err := s.getBuildOccurrence(ctx, log, request.Id)
 	if err != nil {
 		return nil, err
 	}
 
-	buildOccurrences := &pb.BatchCreateOccurrencesRequest{
-		Occurrences: []*grafeas_go_proto.Occurrence{buildOccurrence},
+	return mapBuildOccurrenceToBuild(occurrence), nil
+}
+
+func (s *BuildCollectorServer) GetBuildProvenance(ctx context.Context, request *v1alpha1.GetBuildProvenanceRequest) (*v1alpha1.GetBuildProvenanceResponse, error) {
+	log := s.logger.Named("GetBuildProvenance").With(zap.String("id", request.Id))
+	log.Debug("Received request")
+
+	if len(request.Id) == 0 {
+		return nil, invalidRequestError(newFieldError("id", "build occurrence id must be specified"))
+	}
+
+	occurrence, err := s.getBuildOccurrence(ctx, log, request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if occurrence.GetBuild().GetProvenanceBytes() == "" {
+		return nil, notFoundError(resourceTypeBuildProvenance, request.Id, "No provenance recorded for build occurrence: %s", request.Id)
+	}
+
+	statementJson, envelope, err := provenanceStatement(occurrence)
+	if err != nil {
+		log.Error("Invalid provenance envelope recorded on build occurrence", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "Invalid provenance recorded for build occurrence %s: %s", request.Id, err)
+	}
+
+	statement := &structpb.Struct{}
+	if err := protojson.Unmarshal(statementJson, statement); err != nil {
+		log.Error("Invalid provenance recorded on build occurrence", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "Invalid provenance recorded for build occurrence %s: %s", request.Id, err)
+	}
+
+	return &v1alpha1.GetBuildProvenanceResponse{
+		PredicateType: statement.GetFields()["predicateType"].GetStringValue(),
+		Statement:     statement,
+		Envelope:      envelope,
+	}, nil
+}
+
+func (s *BuildCollectorServer) VerifyBuild(ctx context.Context, request *v1alpha1.VerifyBuildRequest) (*v1alpha1.VerifyBuildResponse, error) {
+	log := s.logger.Named("VerifyBuild").With(zap.String("id", request.Id))
+	log.Debug("Received request")
+
+	if len(request.Id) == 0 {
+		return nil, invalidRequestError(newFieldError("id", "build occurrence id must be specified"))
+	}
+
+	if s.config.SigningKey == nil {
+		return nil, status.Error(codes.FailedPrecondition, "No signing key is configured to verify builds with")
+	}
+
+	occurrence, err := s.getBuildOccurrence(ctx, log, request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	reason, err := verifyBuildOccurrence(s.config.SigningKey, occurrence)
+	if err != nil {
+		log.Error("Error verifying build occurrence", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "Error verifying build occurrence %s: %s", request.Id, err)
+	}
+
+	if reason != "" {
+		log.Info("Build occurrence could not be verified", zap.String("reason", reason))
+	}
+
+	return &v1alpha1.VerifyBuildResponse{
+		Verified: reason == "",
+		KeyId:    occurrence.GetBuild().GetProvenance().GetBuildOptions()[signatureKeyIdBuildOption],
+		Reason:   reason,
+	}, nil
+}
+
+func (s *BuildCollectorServer) GetSigningKey(_ context.Context, _ *v1alpha1.GetSigningKeyRequest) (*v1alpha1.GetSigningKeyResponse, error) {
+	log := s.logger.Named("GetSigningKey")
+	log.Debug("Received request")
+
+	if s.config.SigningKey == nil {
+		return nil, status.Error(codes.FailedPrecondition, "No signing key is configured")
+	}
+
+	publicKey, err := signingPublicKeyPem(s.config.SigningKey)
+	if err != nil {
+		log.Error("Error encoding signing public key", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "Error encoding signing public key: %s", err)
+	}
+
+	return &v1alpha1.GetSigningKeyResponse{
+		KeyId:       s.config.SigningKey.Id,
+		PublicKey:   publicKey,
+		PayloadType: signedBuildPayloadType,
+	}, nil
+}
+
+func (s *BuildCollectorServer) AttachSbom(ctx context.Context, request *v1alpha1.AttachSbomRequest) (*v1alpha1.AttachSbomResponse, error) {
+	log := s.logger.Named("AttachSbom").With(zap.String("buildId", request.BuildId), zap.String("artifactId", request.ArtifactId))
+	log.Debug("Received request", zap.Int("size", len(request.Document)))
+
+	if err := checkSbomSize(len(request.Document), s.config.MaxSbomSize); err != nil {
+		return nil, invalidRequestError(err)
+	}
+
+	return s.attachSbom(ctx, log, request)
+}
+
+func (s *BuildCollectorServer) UploadSbom(stream v1alpha1.BuildCollector_UploadSbomServer) error {
+	log := s.logger.Named("UploadSbom")
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	request := first.GetMetadata()
+	if request == nil {
+		return invalidRequestError(newFieldError("metadata", "the first message must set the SBOM metadata"))
+	}
+	log = log.With(zap.String("buildId", request.BuildId), zap.String("artifactId", request.ArtifactId))
+
+	if err := checkSbomSize(len(request.Document), s.config.MaxSbomSize); err != nil {
+		log.Info("SBOM upload is too large", zap.Int("size", len(request.Document)))
+		return invalidRequestError(err)
+	}
+
+	document := bytes.NewBuffer(request.Document)
+	for {
+		message, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if message.GetMetadata() != nil {
+			return invalidRequestError(newFieldError("metadata", "SBOM metadata can only be set by the first message"))
+		}
+
+		if err := checkSbomSize(document.Len()+len(message.GetChunk()), s.config.MaxSbomSize); err != nil {
+			log.Info("SBOM upload is too large", zap.Int("size", document.Len()))
+			return invalidRequestError(err)
+		}
+		document.Write(message.GetChunk())
+	}
+	log.Debug("Received SBOM", zap.Int("size", document.Len()))
+
+	request = &v1alpha1.AttachSbomRequest{
+		BuildId:    request.BuildId,
+		ArtifactId: request.ArtifactId,
+		Format:     request.Format,
+		Document:   document.Bytes(),
+	}
+
+	response, err := s.attachSbom(stream.Context(), log, request)
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(response)
+}
+
+// attachSbom validates the SBOM, checks that it describes the artifact on the build, then records it as an
+// attestation on the artifact and links it to the build occurrence
+func (s *BuildCollectorServer) attachSbom(ctx context.Context, log *zap.Logger, request *v1alpha1.AttachSbomRequest) (*v1alpha1.AttachSbomResponse, error) {
+	if err := validateAttachSbomRequest(request); err != nil {
+		return nil, invalidRequestError(err)
+	}
+
+	format, components, err := parseSbom(request.Format, request.Document)
+	if err != nil {
+		log.Info("Invalid SBOM", zap.Error(err))
+		return nil, invalidRequestError(fieldErrorFrom("document", err))
+	}
+
+	artifact, err := normalizeArtifact(&v1alpha1.Artifact{Id: request.ArtifactId})
+	if err != nil {
+		return nil, invalidRequestError(fieldErrorFrom("artifact_id", err))
+	}
+
+	buildOccurrence, err := s.getBuildOccurrence(ctx, log, request.BuildId)
+	if err != nil {
+		return nil, err
+	}
+
+	builtArtifact := findBuiltArtifact(buildOccurrence, artifact.Id, strings.TrimSpace(request.ArtifactId))
+	if builtArtifact == nil {
+		return nil, notFoundError(resourceTypeArtifact, artifact.Id, "Artifact %s not found on build occurrence %s", artifact.Id, request.BuildId)
+	}
+
+	digest := artifact.Digest
+	if digest == nil {
+		digest = builtArtifactDigest(builtArtifact)
+	}
+
+	if digest == nil {
+		return nil, invalidRequestError(newFieldError("artifact_id", "artifact %s has no digest to match the SBOM against", request.ArtifactId))
+	}
+
+	component := findSbomComponent(components, digest)
+	if component == nil {
+		log.Info("SBOM doesn't describe the artifact", zap.String("digest", digest.Algorithm+":"+digest.Hex))
+		return nil, invalidRequestError(newFieldError("document", "the SBOM doesn't describe a component with digest %s:%s", digest.Algorithm, digest.Hex))
 	}
 
 	log.Debug("Calling BatchCreateOccurrences")
-	response, err := s.rode.BatchCreateOccurrences(ctx, buildOccurrences)
+	response, err := s.rode.BatchCreateOccurrences(ctx, &pb.BatchCreateOccurrencesRequest{
+		Occurrences: []*grafeas_go_proto.Occurrence{mapSbomToOccurrence(artifact.Id, request.Document)},
+	})
 	if err != nil {
 		log.Error("Error occurred when calling BatchCreateOccurrences", zap.Error(err))
 
-		return nil, status.Errorf(status.Code(err), "Error creating occurrences in Rode: %s", err)
+		return nil, rodeError(err, reasonRodeCreateOccurrencesFailed, "Error creating SBOM occurrence in Rode").Err()
 	}
 
 	if len(response.Occurrences) != 1 {
 		log.Warn("Did not get expected occurrences from Rode", zap.Any("response", response))
-		return nil, status.Error(codes.Internal, "Occurrence data not returned from Rode")
+		return nil, internalRodeError(reasonRodeMissingOccurrenceData, "Occurrence data not returned from Rode").Err()
 	}
 
-	newOccurrence := response.Occurrences[0]
+	sbomOccurrenceId := extractOccurrenceIdFromName(response.Occurrences[0].Name)
+	if err := s.linkSbomToBuild(ctx, log, request.BuildId, sbomOccurrenceId); err != nil {
+		log.Error("SBOM recorded but not linked to the build occurrence", zap.String("sbomOccurrenceId", sbomOccurrenceId))
+		return nil, unlinkedSbomError(err, sbomOccurrenceId, request.BuildId)
+	}
 
-	return &v1alpha1.CreateBuildResponse{
-		BuildOccurrenceId: extractOccurrenceIdFromName(newOccurrence.Name),
+	return &v1alpha1.AttachSbomResponse{
+		SbomOccurrenceId: sbomOccurrenceId,
+		Format:           format,
+		ComponentName:    component.name,
 	}, nil
 }
 
-func (s *BuildCollectorServer) UpdateBuildArtifacts(ctx context.Context, request *v1alpha1.UpdateBuildArtifactsRequest) (*v1alpha1.UpdateBuildArtifactsResponse, error) {
-	log := s.logger.Named("UpdateBuildArtifacts").With(zap.String("existingArtifact", request.ExistingArtifactId), zap.Any("newArtifact", request.NewArtifact))
-	log.Debug("Received request")
+// linkSbomToBuild adds the SBOM occurrence to the build occurrence's list, re-signing the build when signing is enabled
+func (s *BuildCollectorServer) linkSbomToBuild(ctx context.Context, log *zap.Logger, buildOccurrenceId, sbomOccurrenceId string) error {
+	unlock := s.locks.lock(buildOccurrenceId)
+	defer unlock()
 
-	if err := validateUpdateBuildArtifactsRequest(request); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "Invalid request: %s", err)
+	occurrence, err := s.getBuildOccurrence(ctx, log, buildOccurrenceId)
+	if err != nil {
+		return err
+	}
+
+	provenance := occurrence.GetBuild().Provenance
+	if provenance.BuildOptions == nil {
+		provenance.BuildOptions = map[string]string{}
+	}
+	sbomOccurrenceIds := append(parseSbomOccurrenceIds(provenance.BuildOptions), sbomOccurrenceId)
+	provenance.BuildOptions[sbomOccurrenceIdsBuildOption] = strings.Join(sbomOccurrenceIds, ",")
+
+	if err := signBuildOccurrence(s.config, occurrence); err != nil {
+		log.Error("Error signing build occurrence", zap.Error(err))
+		return status.Errorf(codes.Internal, "Error signing build occurrence: %s", err)
+	}
+
+	_, err = s.rode.UpdateOccurrence(ctx, &pb.UpdateOccurrenceRequest{
+		Id:         buildOccurrenceId,
+		Occurrence: occurrence,
+		UpdateMask: &field_mask.FieldMask{
+			Paths: []string{"details.build.provenance.build_options"},
+		},
+	})
+	if err != nil {
+		log.Error("Error calling UpdateOccurrence", zap.Error(err))
+
+		return rodeError(err, reasonRodeUpdateOccurrenceFailed, "Error linking SBOM to build occurrence in Rode").Err()
 	}
 
-	artifactFilter := fmt.Sprintf(buildOccurrenceArtifactFilter, request.ExistingArtifactId)
+	return nil
+}
 
-	response, err := s.rode.ListOccurrences(ctx, &pb.ListOccurrencesRequest{Filter: artifactFilter})
+func (s *BuildCollectorServer) getBuildOccurrence(ctx context.Context, log *zap.Logger, buildOccurrenceId string) (*grafeas_go_proto.Occurrence, error) {
+	occurrenceName := fmt.Sprintf("%s/occurrences/%s", rodeProjectId, buildOccurrenceId)
+	response, err := s.rode.ListOccurrences(ctx, &pb.ListOccurrencesRequest{
+		Filter: fmt.Sprintf(buildOccurrenceNameFilter, strconv.Quote(occurrenceName), s.notesFilter()),
+	})
 	if err != nil {
 		log.Error("Error occurred when calling ListOccurrences", zap.Error(err))
 
-		return nil, status.Errorf(status.Code(err), "Error finding existing artifact in Rode: %s", err)
+		return nil, rodeError(err, reasonRodeListOccurrencesFailed, "Error finding build occurrence in Rode").Err()
 	}
 	log.Debug("ListOccurrences response", zap.Any("response", response))
 
-	if len(response.Occurrences) == 0 {
-		log.Error("No occurrence found for artifact")
-		return nil, status.Errorf(codes.NotFound, "No occurrence found for artifact: %s", request.ExistingArtifactId)
+	if len(response.Occurrences) == 0 || response.Occurrences[0].GetBuild().GetProvenance() == nil {
+		log.Error("No build occurrence found")
+		return nil, notFoundError(resourceTypeBuildOccurrence, buildOccurrenceId, "No build occurrence found with id: %s", buildOccurrenceId)
 	}
 
-	if len(response.Occurrences) > 1 {
-		log.Warn("More than one occurrence found for artifact, taking earliest")
+	return response.Occurrences[0], nil
+}
+
+func (s *BuildCollectorServer) ListBuilds(ctx context.Context, request *v1alpha1.ListBuildsRequest) (*v1alpha1.ListBuildsResponse, error) {
+	log := s.logger.Named("ListBuilds")
+	request = s.redactor.redactListBuildsRequest(request)
+	log.Debug("Received request", zap.Any("request", request))
+
+	notesFilter := s.notesFilter()
+	if request.Note != "" {
+		noteName, err := s.config.NoteName(request.Note)
+		if err != nil {
+			return nil, invalidRequestError(fieldErrorFrom("note", err))
+		}
+		notesFilter = fmt.Sprintf(buildOccurrenceNoteFilter, strconv.Quote(noteName))
 	}
 
-	sort.Slice(response.Occurrences, func(l, r int) bool {
-		left := response.Occurrences[l].CreateTime.AsTime()
-		right := response.Occurrences[r].CreateTime.AsTime()
+	filter, err := buildListBuildsFilter(request, notesFilter)
+	if err != nil {
+		return nil, invalidRequestError(err)
+	}
 
-		return left.Before(right)
+	response, err := s.rode.ListOccurrences(ctx, &pb.ListOccurrencesRequest{
+		Filter:    filter,
+		PageSize:  request.PageSize,
+		PageToken: request.PageToken,
 	})
-	occurrence := response.Occurrences[0]
+	if err != nil {
+		log.Error("Error occurred when calling ListOccurrences", zap.Error(err))
 
-	occurrence.GetBuild().Provenance.BuiltArtifacts = append(
-		occurrence.GetBuild().Provenance.BuiltArtifacts,
-		&provenance_go_proto.Artifact{
-			Id:    request.NewArtifact.Id,
-			Names: request.NewArtifact.Names,
-		},
-	)
+		return nil, rodeError(err, reasonRodeListOccurrencesFailed, "Error listing build occurrences in Rode").Err()
+	}
+	log.Debug("ListOccurrences response", zap.Any("response", response))
 
-	res, err := s.rode.UpdateOccurrence(ctx, &pb.UpdateOccurrenceRequest{
-		Id:         extractOccurrenceIdFromName(occurrence.Name),
-		Occurrence: occurrence,
-		UpdateMask: &field_mask.FieldMask{
-			Paths: []string{"details.build.provenance.built_artifacts"},
-		}})
+	var builds []*v1alpha1.Build
+	for _, occurrence := range response.Occurrences {
+		if occurrence.GetBuild().GetProvenance() == nil {
+			log.Warn("Skipping occurrence without build provenance", zap.String("name", occurrence.Name))
+			continue
+		}
 
-	if err != nil {
-		log.Error("Error calling UpdateOccurrence", zap.Error(err))
+		builds = append(builds, mapBuildOccurrenceToBuild(occurrence))
+	}
+
+	return &v1alpha1.ListBuildsResponse{
+		Builds:        builds,
+		NextPageToken: response.NextPageToken,
+	}, nil
+}
+
+func buildListBuildsFilter(request *v1alpha1.ListBuildsRequest, notesFilter string) (string, error) {
+	filters := []string{notesFilter}
 
-		return nil, status.Errorf(status.Code(err), "Error updating existing artifact in Rode: %s", err)
+	if request.Repository != "" {
+		repositoryUri, err := buildRepositoryResourceUri(request.Repository)
+		if err != nil {
+			return "", fmt.Errorf("invalid repository url: %s", err)
+		}
+
+		filters = append(filters, fmt.Sprintf(buildOccurrenceResourceFilter, strconv.Quote(repositoryUri+"@")))
 	}
 
-	log.Debug("UpdateOccurrence response", zap.Any("response", res))
+	if request.CommitId != "" {
+		filters = append(filters, fmt.Sprintf(buildOccurrenceCommitFilter, strconv.Quote(request.CommitId)))
+	}
 
-	return &v1alpha1.UpdateBuildArtifactsResponse{
-		BuildOccurrenceId: extractOccurrenceIdFromName(res.Name),
-	}, nil
+	if request.Creator != "" {
+		filters = append(filters, fmt.Sprintf(buildOccurrenceCreatorFilter, strconv.Quote(request.Creator)))
+	}
+
+	if request.ArtifactId != "" {
+		artifactIds, err := artifactLookupIds(request.ArtifactId)
+		if err != nil {
+			return "", err
+		}
+
+		filters = append(filters, builtArtifactsFilter(artifactIds))
+	}
+
+	if request.BuildStartAfter != nil {
+		if !request.BuildStartAfter.IsValid() {
+			return "", errors.New("invalid build start after time")
+		}
+
+		filters = append(filters, fmt.Sprintf(buildOccurrenceStartFilter, ">=", strconv.Quote(request.BuildStartAfter.AsTime().Format(time.RFC3339Nano))))
+	}
+
+	if request.BuildStartBefore != nil {
+		if !request.BuildStartBefore.IsValid() {
+			return "", errors.New("invalid build start before time")
+		}
+
+		filters = append(filters, fmt.Sprintf(buildOccurrenceStartFilter, "<=", strconv.Quote(request.BuildStartBefore.AsTime().Format(time.RFC3339Nano))))
+	}
+
+	return strings.Join(filters, " && "), nil
+}
+
+// notesFilter matches occurrences that reference any of the configured notes, or the legacy note
+func (s *BuildCollectorServer) notesFilter() string {
+	return anyNoteFilter(s.config.ReadNoteNames())
+}
+
+// idempotencyNotesFilter matches occurrences that a build recorded against the note could duplicate. Builds recorded
+// against the default note before it was registered with Rode reference the legacy note instead.
+func (s *BuildCollectorServer) idempotencyNotesFilter(noteName string) string {
+	if defaultNoteName, _ := s.config.NoteName(""); noteName == defaultNoteName && noteName != config.LegacyNoteName {
+		return anyNoteFilter([]string{noteName, config.LegacyNoteName})
+	}
+
+	return anyNoteFilter([]string{noteName})
+}
+
+func anyNoteFilter(noteNames []string) string {
+	var filters []string
+	for _, noteName := range noteNames {
+		filters = append(filters, fmt.Sprintf(buildOccurrenceNoteFilter, strconv.Quote(noteName)))
+	}
+
+	if len(filters) == 1 {
+		return filters[0]
+	}
+
+	return "(" + strings.Join(filters, " || ") + ")"
 }
 
 func validateUpdateBuildArtifactsRequest(request *v1alpha1.UpdateBuildArtifactsRequest) error {
 	if request.NewArtifact == nil {
-		return errors.New("new artifact must be specified")
+		return newFieldError("new_artifact", "new artifact must be specified")
 	}
 
-	if len(request.ExistingArtifactId) == 0 {
-		return errors.New("existing artifact must be specified")
+	if len(request.ExistingArtifactId) == 0 && len(request.BuildOccurrenceId) == 0 {
+		return newFieldError("existing_artifact_id", "existing artifact or build occurrence id must be specified")
 	}
 
 	return nil
 }
 
-func validateCreateBuildRequest(request *v1alpha1.CreateBuildRequest) error {
-	if len(request.Repository) == 0 {
-		return errors.New("no repository specified")
+func validateReplaceBuildArtifactsRequest(request *v1alpha1.ReplaceBuildArtifactsRequest) error {
+	if len(request.ExistingArtifactId) == 0 && len(request.BuildOccurrenceId) == 0 {
+		return newFieldError("existing_artifact_id", "existing artifact or build occurrence id must be specified")
+	}
+
+	if len(request.Artifacts) == 0 {
+		return newFieldError("artifacts", "no artifacts specified")
+	}
+
+	for i, artifact := range request.Artifacts {
+		if len(artifact.GetId()) == 0 {
+			return newFieldError(fmt.Sprintf("artifacts[%d].id", i), "artifact id must be specified")
+		}
+	}
+
+	return nil
+}
+
+func validateAttachSbomRequest(request *v1alpha1.AttachSbomRequest) error {
+	if request.BuildId == "" {
+		return newFieldError("build_id", "build occurrence id must be specified")
+	}
+
+	if request.ArtifactId == "" {
+		return newFieldError("artifact_id", "artifact id must be specified")
 	}
 
+	if len(request.Document) == 0 {
+		return newFieldError("document", "SBOM document must be specified")
+	}
+
+	return nil
+}
+
+func validateCreateBuildRequest(request *v1alpha1.CreateBuildRequest) error {
 	if len(request.Artifacts) == 0 {
-		return errors.New("no artifacts specified")
+		return newFieldError("artifacts", "no artifacts specified")
+	}
+
+	if err := validateSource(request); err != nil {
+		return err
 	}
 
-	if len(request.CommitId) == 0 {
-		return errors.New("no commit ID specified")
+	if err := validateMaterials(request.Materials); err != nil {
+		return err
+	}
+
+	if err := validateBuildSteps(request.Steps); err != nil {
+		return err
+	}
+
+	if err := validateBuildOptions(request.BuildOptions); err != nil {
+		return err
 	}
 
 	return nil
 }
 
-func mapRequestToBuildOccurrence(log *zap.Logger, request *v1alpha1.CreateBuildRequest) (*grafeas_go_proto.Occurrence, error) {
-	repositoryURL, err := url.ParseRequestURI(request.Repository)
+func mapRequestToBuildOccurrence(log *zap.Logger, conf *config.Config, request *v1alpha1.CreateBuildRequest, ingested *provenanceDocument) (*grafeas_go_proto.Occurrence, error) {
+	noteName, err := conf.NoteName(request.Note)
+	if err != nil {
+		log.Error("Invalid note", zap.Error(err))
+		return nil, invalidRequestError(fieldErrorFrom("note", err))
+	}
+
+	resourceUri, source, err := mapRequestToSource(request)
 	if err != nil {
 		log.Error("Invalid repository url", zap.Error(err))
-		return nil, status.Errorf(codes.InvalidArgument, "Invalid repository url: %s", err)
+		return nil, invalidArgumentError("Invalid repository url", fieldErrorFrom("repository", err))
 	}
+	source.FileHashes = mapMaterialsToFileHashes(request.Materials)
 
 	var artifacts []*provenance_go_proto.Artifact
-	for _, artifact := range request.Artifacts {
+	for i, artifact := range request.Artifacts {
+		normalized, err := normalizeArtifact(artifact)
+		if err != nil {
+			log.Error("Invalid artifact", zap.Error(err))
+			return nil, invalidRequestError(fieldErrorFrom(fmt.Sprintf("artifacts[%d]", i), err))
+		}
+
 		artifacts = append(artifacts, &provenance_go_proto.Artifact{
-			Id:    artifact.Id,
-			Names: artifact.Names,
+			Id:       normalized.Id,
+			Names:    normalized.Names,
+			Checksum: artifactChecksum(normalized),
 		})
 	}
 
-	startTime := getValidTimestamp(request.BuildStart)
-	endTime := getValidTimestamp(request.BuildEnd)
+	now := time.Now()
+	times, err := resolveBuildTimes(conf, request, now)
+	if err != nil {
+		log.Error("Invalid build times", zap.Error(err))
+		return nil, invalidRequestError(err)
+	}
+
+	buildOptions := times.buildOptions()
+	if ingested != nil {
+		for key, value := range ingested.buildOptions() {
+			buildOptions[key] = value
+		}
+	}
 
-	return &grafeas_go_proto.Occurrence{
+	occurrence := &grafeas_go_proto.Occurrence{
 		Resource: &grafeas_go_proto.Resource{
-			Uri: fmt.Sprintf("git://%s%s@%s", repositoryURL.Host, repositoryURL.Path, request.CommitId),
+			Uri: resourceUri,
 		},
-		NoteName: buildCollectorNote,
+		NoteName: noteName,
 		Kind:     common_go_proto.NoteKind_BUILD,
 		Details: &grafeas_go_proto.Occurrence_Build{
 			Build: &build_go_proto.Details{
 				Provenance: &provenance_go_proto.BuildProvenance{
-					Id:             request.ProvenanceId,
-					ProjectId:      rodeProjectId,
-					BuiltArtifacts: artifacts,
-					Creator:        request.Creator,
-					CreateTime:     timestamppb.Now(),
-					StartTime:      startTime,
-					EndTime:        endTime,
-					LogsUri:        request.LogsUri,
-					SourceProvenance: &provenance_go_proto.Source{
-						Context: &source_go_proto.SourceContext{
-							Context: &source_go_proto.SourceContext_Git{
-								Git: &source_go_proto.GitSourceContext{
-									Url:        request.CommitUri,
-									RevisionId: request.CommitId,
-								}},
-						},
-					},
+					Id:               request.ProvenanceId,
+					ProjectId:        conf.ProjectId,
+					BuiltArtifacts:   artifacts,
+					Creator:          request.Creator,
+					CreateTime:       timestamppb.New(now),
+					StartTime:        timestamppb.New(times.start),
+					EndTime:          timestamppb.New(times.end),
+					LogsUri:          request.LogsUri,
+					Commands:         mapBuildStepsToCommands(request.Steps),
+					BuilderVersion:   request.GetBuilder().GetVersion(),
+					BuildOptions:     mapRequestToBuildOptions(request, buildOptions),
+					SourceProvenance: source,
 				},
 			},
 		},
-	}, nil
-}
+	}
 
-func extractOccurrenceIdFromName(occurrenceName string) string {
-	namePieces := strings.Split(occurrenceName, "/")
+	if ingested != nil {
+		occurrence.GetBuild().ProvenanceBytes = string(ingested.raw)
+	}
 
-	return namePieces[len(namePieces)-1]
+	if err := setSlsaStatement(conf, occurrence); err != nil {
+		log.Error("Error generating SLSA provenance", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "Error generating SLSA provenance: %s", err)
+	}
+
+	if err := signBuildOccurrence(conf, occurrence); err != nil {
+		log.Error("Error signing build occurrence", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "Error signing build occurrence: %s", err)
+	}
+
+	return occurrence, nil
 }
 
-func getValidTimestamp(t *timestamp.Timestamp) *timestamp.Timestamp {
-	if !t.IsValid() {
-		return timestamppb.Now()
+func mapBuildOccurrenceToBuild(occurrence *grafeas_go_proto.Occurrence) *v1alpha1.Build {
+	provenance := occurrence.GetBuild().GetProvenance()
+
+	var artifacts []*v1alpha1.Artifact
+	for _, artifact := range provenance.BuiltArtifacts {
+		artifacts = append(artifacts, &v1alpha1.Artifact{
+			Id:     artifact.Id,
+			Names:  artifact.Names,
+			Digest: builtArtifactDigest(artifact),
+		})
+	}
+
+	builder, buildOptions := mapProvenanceToBuilder(provenance)
+
+	build := &v1alpha1.Build{
+		Id:           extractOccurrenceIdFromName(occurrence.Name),
+		Artifacts:    artifacts,
+		ProvenanceId: provenance.Id,
+		LogsUri:      provenance.LogsUri,
+		Creator:      provenance.Creator,
+		BuildStart:   provenance.StartTime,
+		BuildEnd:     provenance.EndTime,
+		CreateTime:   provenance.CreateTime,
+		NoteName:     occurrence.NoteName,
+		Materials:    mapFileHashesToMaterials(provenance.GetSourceProvenance().GetFileHashes()),
+		Steps:        mapCommandsToBuildSteps(provenance.Commands),
+		Builder:      builder,
+		BuildOptions: buildOptions,
+
+		BuildStartAdjustment: parseTimestampAdjustment(provenance.GetBuildOptions()[buildStartAdjustmentBuildOption]),
+		BuildEndAdjustment:   parseTimestampAdjustment(provenance.GetBuildOptions()[buildEndAdjustmentBuildOption]),
+		ProvenanceKeyId:      provenance.GetBuildOptions()[provenanceKeyIdBuildOption],
+		SignatureKeyId:       provenance.GetBuildOptions()[signatureKeyIdBuildOption],
+		SbomOccurrenceIds:    parseSbomOccurrenceIds(provenance.GetBuildOptions()),
 	}
+	mapSourceToBuild(occurrence.GetResource().GetUri(), provenance.GetSourceProvenance(), build)
+
+	return build
+}
+
+func sortOccurrencesByCreateTime(occurrences []*grafeas_go_proto.Occurrence) {
+	sort.Slice(occurrences, func(l, r int) bool {
+		left := occurrences[l].CreateTime.AsTime()
+		right := occurrences[r].CreateTime.AsTime()
 
-	return t
+		return left.Before(right)
+	})
+}
+
+func extractOccurrenceIdFromName(occurrenceName string) string {
+	namePieces := strings.Split(occurrenceName, "/")
+
+	return namePieces[len(namePieces)-1]
 }
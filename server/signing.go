@@ -0,0 +1,168 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/rode/collector-build/config"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/build_go_proto"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/grafeas_go_proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	signatureBuildOption      = "signature"
+	signatureKeyIdBuildOption = "signature_key_id"
+	// signedBuildPayloadType identifies the canonical JSON form of a build occurrence that signatures are over
+	signedBuildPayloadType = "application/vnd.rode.build-collector.signed-build+json"
+)
+
+// signedBuild is the part of a build occurrence that the collector signs: the repository and commit it was built from,
+// the note it was recorded against, and the build details. The details are encoded as protobuf JSON, without the
+// signature options, and signedBuildPayload makes the whole document canonical.
+type signedBuild struct {
+	ResourceUri string          `json:"resourceUri"`
+	NoteName    string          `json:"noteName"`
+	Build       json.RawMessage `json:"build"`
+}
+
+// signBuildOccurrence signs the occurrence, including the SLSA statement, with the collector's signing key when one
+// is configured. The signature and key id are recorded as reserved build options.
+func signBuildOccurrence(conf *config.Config, occurrence *grafeas_go_proto.Occurrence) error {
+	details := occurrence.GetBuild()
+	if conf.SigningKey == nil || details.GetProvenance() == nil {
+		return nil
+	}
+
+	payload, err := signedBuildPayload(occurrence)
+	if err != nil {
+		return err
+	}
+
+	sig, err := signMessage(conf.SigningKey.PrivateKey, dssePreAuthEncoding(signedBuildPayloadType, payload))
+	if err != nil {
+		return err
+	}
+
+	provenance := details.Provenance
+	if provenance.BuildOptions == nil {
+		provenance.BuildOptions = map[string]string{}
+	}
+	provenance.BuildOptions[signatureBuildOption] = base64.StdEncoding.EncodeToString(sig)
+	provenance.BuildOptions[signatureKeyIdBuildOption] = conf.SigningKey.Id
+
+	return nil
+}
+
+// verifyBuildOccurrence checks the signature on the occurrence against the collector's signing key. It returns why
+// the occurrence couldn't be verified, or an empty string if it was.
+func verifyBuildOccurrence(key *config.SigningKey, occurrence *grafeas_go_proto.Occurrence) (string, error) {
+	buildOptions := occurrence.GetBuild().GetProvenance().GetBuildOptions()
+	if buildOptions[signatureBuildOption] == "" {
+		return "build occurrence is not signed", nil
+	}
+
+	if keyId := buildOptions[signatureKeyIdBuildOption]; keyId != key.Id {
+		return fmt.Sprintf("build occurrence was signed with key %q instead of the collector's key %q", keyId, key.Id), nil
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(buildOptions[signatureBuildOption])
+	if err != nil {
+		return "signature is not valid base64", nil
+	}
+
+	payload, err := signedBuildPayload(occurrence)
+	if err != nil {
+		return "", err
+	}
+
+	if !verifySignature(key.PrivateKey.Public(), dssePreAuthEncoding(signedBuildPayloadType, payload), sig) {
+		return "signature doesn't match the build", nil
+	}
+
+	return "", nil
+}
+
+// signedBuildPayload is the canonical JSON encoding of the signed parts of the occurrence: object keys are sorted,
+// there's no insignificant whitespace, and numbers are kept as they were written. protojson deliberately varies its
+// output, so the details are decoded and encoded again to get a stable form.
+func signedBuildPayload(occurrence *grafeas_go_proto.Occurrence) ([]byte, error) {
+	unsigned := proto.Clone(occurrence.GetBuild()).(*build_go_proto.Details)
+	delete(unsigned.GetProvenance().GetBuildOptions(), signatureBuildOption)
+	delete(unsigned.GetProvenance().GetBuildOptions(), signatureKeyIdBuildOption)
+
+	details, err := protojson.Marshal(unsigned)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := json.Marshal(&signedBuild{
+		ResourceUri: occurrence.GetResource().GetUri(),
+		NoteName:    occurrence.NoteName,
+		Build:       details,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return canonicalJson(document)
+}
+
+// canonicalJson re-encodes a JSON document with encoding/json, which sorts object keys and omits whitespace
+func canonicalJson(document []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(value)
+}
+
+// signingPublicKeyPem returns the public half of the signing key as a PEM encoded PKIX public key, the same form
+// that trusted keys are loaded from
+func signingPublicKeyPem(key *config.SigningKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key.PrivateKey.Public())
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// signMessage signs with Ed25519 over the message itself, or with ECDSA over its SHA-256 digest, to match
+// verifySignature
+func signMessage(key crypto.Signer, message []byte) ([]byte, error) {
+	if _, ok := key.Public().(ed25519.PublicKey); ok {
+		return key.Sign(rand.Reader, message, crypto.Hash(0))
+	}
+
+	digest := sha256.Sum256(message)
+
+	return key.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
@@ -0,0 +1,174 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/rode/collector-build/config"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/grafeas_go_proto"
+	"google.golang.org/protobuf/proto"
+)
+
+func newSigningKey(id string) *config.SigningKey {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	return &config.SigningKey{Id: id, PrivateKey: privateKey}
+}
+
+var _ = Describe("signing", func() {
+	var (
+		conf       *config.Config
+		occurrence *grafeas_go_proto.Occurrence
+	)
+
+	BeforeEach(func() {
+		conf = &config.Config{SigningKey: newSigningKey("collector")}
+		occurrence = makeBuildOccurrence(fake.UUID(), fake.URL())
+		occurrence.Resource = &grafeas_go_proto.Resource{Uri: "git://github.com/rode/collector-build@" + fake.LetterN(10)}
+		occurrence.NoteName = "projects/rode/notes/build_collector-build"
+		occurrence.GetBuild().Provenance.BuildOptions = map[string]string{"GOOS": "linux"}
+		occurrence.GetBuild().ProvenanceBytes = `{"_type":"https://in-toto.io/Statement/v1"}`
+	})
+
+	DescribeTable("signing and verifying", func(signingKey func() *config.SigningKey) {
+		conf.SigningKey = signingKey()
+
+		Expect(signBuildOccurrence(conf, occurrence)).To(Succeed())
+
+		buildOptions := occurrence.GetBuild().Provenance.BuildOptions
+		Expect(buildOptions).To(HaveKey(signatureBuildOption))
+		Expect(buildOptions).To(HaveKeyWithValue(signatureKeyIdBuildOption, "collector"))
+		Expect(buildOptions).To(HaveKeyWithValue("GOOS", "linux"))
+		Expect(verifyBuildOccurrence(conf.SigningKey, occurrence)).To(BeEmpty())
+	},
+		Entry("Ed25519", func() *config.SigningKey {
+			return newSigningKey("collector")
+		}),
+		Entry("ECDSA P-256", func() *config.SigningKey {
+			privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			return &config.SigningKey{Id: "collector", PrivateKey: privateKey}
+		}),
+	)
+
+	It("should not sign when no signing key is configured", func() {
+		conf.SigningKey = nil
+
+		Expect(signBuildOccurrence(conf, occurrence)).To(Succeed())
+		Expect(occurrence.GetBuild().Provenance.BuildOptions).To(Equal(map[string]string{"GOOS": "linux"}))
+	})
+
+	It("should replace an existing signature when re-signing", func() {
+		Expect(signBuildOccurrence(conf, occurrence)).To(Succeed())
+		occurrence.GetBuild().Provenance.BuiltArtifacts = nil
+
+		Expect(signBuildOccurrence(conf, occurrence)).To(Succeed())
+		Expect(verifyBuildOccurrence(conf.SigningKey, occurrence)).To(BeEmpty())
+	})
+
+	Describe("verifyBuildOccurrence", func() {
+		BeforeEach(func() {
+			Expect(signBuildOccurrence(conf, occurrence)).To(Succeed())
+		})
+
+		DescribeTable("occurrences that don't verify", func(modify func(*grafeas_go_proto.Occurrence), expectedReason string) {
+			modify(occurrence)
+
+			Expect(verifyBuildOccurrence(conf.SigningKey, occurrence)).To(ContainSubstring(expectedReason))
+		},
+			Entry("unsigned", func(o *grafeas_go_proto.Occurrence) {
+				delete(o.GetBuild().Provenance.BuildOptions, signatureBuildOption)
+			}, "not signed"),
+			Entry("different key id", func(o *grafeas_go_proto.Occurrence) {
+				o.GetBuild().Provenance.BuildOptions[signatureKeyIdBuildOption] = "someone-else"
+			}, `signed with key "someone-else"`),
+			Entry("signature not base64", func(o *grafeas_go_proto.Occurrence) {
+				o.GetBuild().Provenance.BuildOptions[signatureBuildOption] = "%%%"
+			}, "not valid base64"),
+			Entry("modified build options", func(o *grafeas_go_proto.Occurrence) {
+				o.GetBuild().Provenance.BuildOptions["GOOS"] = "windows"
+			}, "doesn't match"),
+			Entry("modified statement", func(o *grafeas_go_proto.Occurrence) {
+				o.GetBuild().ProvenanceBytes = "{}"
+			}, "doesn't match"),
+			Entry("moved to another repository", func(o *grafeas_go_proto.Occurrence) {
+				o.Resource = &grafeas_go_proto.Resource{Uri: "git://github.com/rode/other@" + fake.LetterN(10)}
+			}, "doesn't match"),
+			Entry("moved to another note", func(o *grafeas_go_proto.Occurrence) {
+				o.NoteName = "projects/rode/notes/release-builds"
+			}, "doesn't match"),
+		)
+
+		It("should reject a signature made with a different key", func() {
+			Expect(verifyBuildOccurrence(newSigningKey("collector"), occurrence)).To(Equal("signature doesn't match the build"))
+		})
+	})
+
+	Describe("signedBuildPayload", func() {
+		It("should encode the same occurrence the same way every time", func() {
+			first, err := signedBuildPayload(occurrence)
+			Expect(err).NotTo(HaveOccurred())
+
+			for i := 0; i < 10; i++ {
+				Expect(signedBuildPayload(proto.Clone(occurrence).(*grafeas_go_proto.Occurrence))).To(Equal(first))
+			}
+		})
+
+		It("should include the resource, note and details without the signature", func() {
+			Expect(signBuildOccurrence(conf, occurrence)).To(Succeed())
+
+			payload, err := signedBuildPayload(occurrence)
+			Expect(err).NotTo(HaveOccurred())
+
+			var document map[string]interface{}
+			Expect(json.Unmarshal(payload, &document)).To(Succeed())
+			Expect(document).To(HaveKeyWithValue("resourceUri", occurrence.Resource.Uri))
+			Expect(document).To(HaveKeyWithValue("noteName", occurrence.NoteName))
+			Expect(document["build"]).To(HaveKeyWithValue("provenanceBytes", occurrence.GetBuild().ProvenanceBytes))
+			Expect(string(payload)).NotTo(ContainSubstring(signatureBuildOption))
+			Expect(string(payload)).NotTo(ContainSubstring(" "))
+		})
+	})
+
+	It("should publish a public key that verifies signatures", func() {
+		Expect(signBuildOccurrence(conf, occurrence)).To(Succeed())
+
+		publicKeyPem, err := signingPublicKeyPem(conf.SigningKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		block, _ := pem.Decode([]byte(publicKeyPem))
+		Expect(block.Type).To(Equal("PUBLIC KEY"))
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		Expect(err).NotTo(HaveOccurred())
+
+		payload, err := signedBuildPayload(occurrence)
+		Expect(err).NotTo(HaveOccurred())
+		sig, err := base64.StdEncoding.DecodeString(occurrence.GetBuild().Provenance.BuildOptions[signatureBuildOption])
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(verifySignature(publicKey, dssePreAuthEncoding(signedBuildPayloadType, payload), sig)).To(BeTrue())
+	})
+})
@@ -0,0 +1,411 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rode/collector-build/config"
+	"github.com/rode/collector-build/proto/v1alpha1"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/grafeas_go_proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	inTotoStatementV01Type = "https://in-toto.io/Statement/v0.1"
+	inTotoStatementV1Type  = "https://in-toto.io/Statement/v1"
+
+	slsaV02PredicateType = "https://slsa.dev/provenance/v0.2"
+	slsaV1PredicateType  = "https://slsa.dev/provenance/v1"
+
+	// defaultSlsaBuilderId is recorded as the builder when the build didn't report one
+	defaultSlsaBuilderId = "https://github.com/rode/collector-build"
+
+	cloudRepoSourceUriFormat = "git+https://source.developers.google.com/p/%s/r/%s"
+)
+
+type inTotoStatement struct {
+	Type          string          `json:"_type"`
+	Subject       []inTotoSubject `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     interface{}     `json:"predicate"`
+}
+
+type inTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+type slsaBuildStep struct {
+	Id         string   `json:"id,omitempty"`
+	Name       string   `json:"name"`
+	Args       []string `json:"args,omitempty"`
+	WorkingDir string   `json:"workingDir,omitempty"`
+	WaitFor    []string `json:"waitFor,omitempty"`
+}
+
+type slsaV02Predicate struct {
+	Builder     slsaV02Builder      `json:"builder"`
+	BuildType   string              `json:"buildType"`
+	Invocation  slsaV02Invocation   `json:"invocation"`
+	BuildConfig *slsaV02BuildConfig `json:"buildConfig,omitempty"`
+	Metadata    slsaV02Metadata     `json:"metadata"`
+	Materials   []slsaV02Material   `json:"materials,omitempty"`
+}
+
+type slsaV02Builder struct {
+	Id string `json:"id"`
+}
+
+type slsaV02Invocation struct {
+	ConfigSource slsaV02Material   `json:"configSource"`
+	Parameters   map[string]string `json:"parameters,omitempty"`
+}
+
+type slsaV02BuildConfig struct {
+	Steps []slsaBuildStep `json:"steps"`
+}
+
+type slsaV02Metadata struct {
+	BuildInvocationId string              `json:"buildInvocationId,omitempty"`
+	BuildStartedOn    string              `json:"buildStartedOn,omitempty"`
+	BuildFinishedOn   string              `json:"buildFinishedOn,omitempty"`
+	Completeness      slsaV02Completeness `json:"completeness"`
+	Reproducible      bool                `json:"reproducible"`
+}
+
+type slsaV02Completeness struct {
+	Parameters  bool `json:"parameters"`
+	Environment bool `json:"environment"`
+	Materials   bool `json:"materials"`
+}
+
+type slsaV02Material struct {
+	Uri    string            `json:"uri,omitempty"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+type slsaV1Predicate struct {
+	BuildDefinition slsaV1BuildDefinition `json:"buildDefinition"`
+	RunDetails      slsaV1RunDetails      `json:"runDetails"`
+}
+
+type slsaV1BuildDefinition struct {
+	BuildType            string                   `json:"buildType"`
+	ExternalParameters   slsaV1ExternalParameters `json:"externalParameters"`
+	ResolvedDependencies []slsaResourceDescriptor `json:"resolvedDependencies,omitempty"`
+}
+
+type slsaV1ExternalParameters struct {
+	Source       slsaV1Source      `json:"source"`
+	Steps        []slsaBuildStep   `json:"steps,omitempty"`
+	BuildOptions map[string]string `json:"buildOptions,omitempty"`
+}
+
+type slsaV1Source struct {
+	Uri    string            `json:"uri"`
+	Digest map[string]string `json:"digest,omitempty"`
+	Ref    string            `json:"ref,omitempty"`
+}
+
+type slsaV1RunDetails struct {
+	Builder    slsaV1Builder            `json:"builder"`
+	Metadata   slsaV1Metadata           `json:"metadata"`
+	Byproducts []slsaResourceDescriptor `json:"byproducts,omitempty"`
+}
+
+type slsaV1Builder struct {
+	Id      string            `json:"id"`
+	Version map[string]string `json:"version,omitempty"`
+}
+
+type slsaV1Metadata struct {
+	InvocationId string `json:"invocationId,omitempty"`
+	StartedOn    string `json:"startedOn,omitempty"`
+	FinishedOn   string `json:"finishedOn,omitempty"`
+}
+
+type slsaResourceDescriptor struct {
+	Name   string            `json:"name,omitempty"`
+	Uri    string            `json:"uri,omitempty"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// setSlsaStatement records an in-toto statement with a SLSA provenance predicate in the provenance bytes of the
+// occurrence. The statement is generated from the occurrence itself, so it can be refreshed whenever the occurrence
+// changes. Builds without any artifact digests can't be the subject of a statement, so nothing is recorded for them.
+// Provenance that was ingested is kept as it was received.
+func setSlsaStatement(conf *config.Config, occurrence *grafeas_go_proto.Occurrence) error {
+	details := occurrence.GetBuild()
+	if details == nil || !slsaEnabled(conf) || isIngestedProvenance(occurrence) {
+		return nil
+	}
+
+	statement, err := newSlsaStatement(conf, mapBuildOccurrenceToBuild(occurrence))
+	if err != nil {
+		return err
+	}
+
+	details.ProvenanceBytes = string(statement)
+
+	return nil
+}
+
+func slsaEnabled(conf *config.Config) bool {
+	return conf.SlsaVersion == config.SlsaVersionV02 || conf.SlsaVersion == config.SlsaVersionV1
+}
+
+// newSlsaStatement generates the JSON encoded statement for a build, or nil if SLSA provenance is disabled or the
+// build has no artifacts with digests
+func newSlsaStatement(conf *config.Config, build *v1alpha1.Build) ([]byte, error) {
+	subjects := slsaSubjects(build.Artifacts)
+	if len(subjects) == 0 {
+		return nil, nil
+	}
+
+	statement := &inTotoStatement{Subject: subjects}
+	switch conf.SlsaVersion {
+	case config.SlsaVersionV02:
+		statement.Type = inTotoStatementV01Type
+		statement.PredicateType = slsaV02PredicateType
+		statement.Predicate = newSlsaV02Predicate(conf, build)
+	case config.SlsaVersionV1:
+		statement.Type = inTotoStatementV1Type
+		statement.PredicateType = slsaV1PredicateType
+		statement.Predicate = newSlsaV1Predicate(conf, build)
+	default:
+		return nil, nil
+	}
+
+	statementJson, err := json.Marshal(statement)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding slsa statement: %w", err)
+	}
+
+	return statementJson, nil
+}
+
+func newSlsaV02Predicate(conf *config.Config, build *v1alpha1.Build) *slsaV02Predicate {
+	uri, digest, _ := slsaSource(build, config.SlsaVersionV02)
+	predicate := &slsaV02Predicate{
+		Builder:   slsaV02Builder{Id: slsaBuilderId(build)},
+		BuildType: conf.SlsaBuildType,
+		Invocation: slsaV02Invocation{
+			ConfigSource: slsaV02Material{Uri: uri, Digest: digest},
+			Parameters:   build.BuildOptions,
+		},
+		Metadata: slsaV02Metadata{
+			BuildInvocationId: build.ProvenanceId,
+			BuildStartedOn:    slsaTimestamp(build.BuildStart),
+			BuildFinishedOn:   slsaTimestamp(build.BuildEnd),
+		},
+		Materials: []slsaV02Material{{Uri: uri, Digest: digest}},
+	}
+
+	if steps := slsaBuildSteps(build.Steps); len(steps) != 0 {
+		predicate.BuildConfig = &slsaV02BuildConfig{Steps: steps}
+	}
+
+	for _, material := range build.Materials {
+		predicate.Materials = append(predicate.Materials, slsaV02Material{Uri: material.Uri, Digest: material.Digest})
+	}
+
+	return predicate
+}
+
+func newSlsaV1Predicate(conf *config.Config, build *v1alpha1.Build) *slsaV1Predicate {
+	uri, digest, ref := slsaSource(build, config.SlsaVersionV1)
+	predicate := &slsaV1Predicate{
+		BuildDefinition: slsaV1BuildDefinition{
+			BuildType: conf.SlsaBuildType,
+			ExternalParameters: slsaV1ExternalParameters{
+				Source:       slsaV1Source{Uri: uri, Digest: digest, Ref: ref},
+				Steps:        slsaBuildSteps(build.Steps),
+				BuildOptions: build.BuildOptions,
+			},
+			ResolvedDependencies: []slsaResourceDescriptor{{Uri: uri, Digest: digest}},
+		},
+		RunDetails: slsaV1RunDetails{
+			Builder: slsaV1Builder{Id: slsaBuilderId(build)},
+			Metadata: slsaV1Metadata{
+				InvocationId: build.ProvenanceId,
+				StartedOn:    slsaTimestamp(build.BuildStart),
+				FinishedOn:   slsaTimestamp(build.BuildEnd),
+			},
+		},
+	}
+
+	for _, material := range build.Materials {
+		predicate.BuildDefinition.ResolvedDependencies = append(predicate.BuildDefinition.ResolvedDependencies, slsaResourceDescriptor{Uri: material.Uri, Digest: material.Digest})
+	}
+
+	if version := build.GetBuilder().GetVersion(); version != "" {
+		predicate.RunDetails.Builder.Version = map[string]string{"builder": version}
+	}
+
+	if build.LogsUri != "" {
+		predicate.RunDetails.Byproducts = []slsaResourceDescriptor{{Name: "logs", Uri: build.LogsUri}}
+	}
+
+	return predicate
+}
+
+// slsaSubjects maps the artifacts that have a digest to statement subjects, sorted by name for a stable encoding
+func slsaSubjects(artifacts []*v1alpha1.Artifact) []inTotoSubject {
+	var subjects []inTotoSubject
+	for _, artifact := range artifacts {
+		name, digest := slsaSubject(artifact)
+		if digest == nil {
+			continue
+		}
+
+		subjects = append(subjects, inTotoSubject{
+			Name: name,
+			Digest: map[string]string{
+				digest.Algorithm: digest.Hex,
+			},
+		})
+	}
+
+	sort.SliceStable(subjects, func(i, j int) bool {
+		return subjects[i].Name < subjects[j].Name
+	})
+
+	return subjects
+}
+
+// slsaSubject names an artifact by its id without the digest, falling back to the first of its names or the id itself.
+// Generic files are stored as file://sha256:<hex>:<name> resource uris, so the name and digest are parsed out of those.
+func slsaSubject(artifact *v1alpha1.Artifact) (string, *v1alpha1.Digest) {
+	if strings.HasPrefix(artifact.Id, archiveResourceUriPrefix) {
+		pieces := strings.SplitN(strings.TrimPrefix(artifact.Id, archiveResourceUriPrefix), ":", 3)
+		if len(pieces) == 3 {
+			if _, err := hex.DecodeString(pieces[1]); err == nil {
+				return pieces[2], &v1alpha1.Digest{Algorithm: pieces[0], Hex: pieces[1]}
+			}
+		}
+	}
+
+	digest := artifact.Digest
+	if digest == nil {
+		digest = parseArtifactDigest(artifact.Id)
+	}
+
+	name, _ := splitArtifactId(artifact.Id)
+	if name == "" {
+		name = artifact.Id
+		if len(artifact.Names) != 0 {
+			name = artifact.Names[0]
+		}
+	}
+
+	return name, digest
+}
+
+// slsaSource returns the uri, digest and ref of the source that was built
+func slsaSource(build *v1alpha1.Build, version config.SlsaVersion) (string, map[string]string, string) {
+	switch source := build.Source.(type) {
+	case *v1alpha1.Build_Archive:
+		var digest map[string]string
+		if source.Archive.Digest != nil {
+			digest = map[string]string{source.Archive.Digest.Algorithm: source.Archive.Digest.Hex}
+		}
+
+		return source.Archive.Uri, digest, ""
+	case *v1alpha1.Build_CloudRepo:
+		uri := fmt.Sprintf(cloudRepoSourceUriFormat, source.CloudRepo.ProjectId, source.CloudRepo.RepoName)
+
+		return uri, gitCommitDigest(build.CommitId, version), source.CloudRepo.AliasName
+	case *v1alpha1.Build_Git:
+		ref := ""
+		switch alias := source.Git.RevisionAlias.(type) {
+		case *v1alpha1.GitSource_Branch:
+			ref = "refs/heads/" + alias.Branch
+		case *v1alpha1.GitSource_Tag:
+			ref = "refs/tags/" + alias.Tag
+		case *v1alpha1.GitSource_Ref:
+			ref = alias.Ref
+		}
+
+		return gitSourceUri(build.Repository), gitCommitDigest(build.CommitId, version), ref
+	}
+
+	return gitSourceUri(build.Repository), gitCommitDigest(build.CommitId, version), ""
+}
+
+// gitSourceUri converts a normalized git:// repository resource uri to the git+https form used in SLSA provenance
+func gitSourceUri(repository string) string {
+	if strings.HasPrefix(repository, "git://") {
+		return "git+https://" + strings.TrimPrefix(repository, "git://")
+	}
+
+	return repository
+}
+
+// gitCommitDigest records a commit under gitCommit, as SLSA v1 does. SLSA v0.2 provenance uses the digest algorithm
+// matching the length of the commit SHA instead, or gitCommit for anything else.
+func gitCommitDigest(commitId string, version config.SlsaVersion) map[string]string {
+	if commitId == "" {
+		return nil
+	}
+
+	algorithm := "gitCommit"
+	if _, err := hex.DecodeString(commitId); err == nil && version == config.SlsaVersionV02 {
+		switch len(commitId) {
+		case artifactDigestHexLengths["sha1"]:
+			algorithm = "sha1"
+		case artifactDigestHexLengths["sha256"]:
+			algorithm = "sha256"
+		}
+	}
+
+	return map[string]string{algorithm: strings.ToLower(commitId)}
+}
+
+func slsaBuilderId(build *v1alpha1.Build) string {
+	if id := build.GetBuilder().GetId(); id != "" {
+		return id
+	}
+
+	return defaultSlsaBuilderId
+}
+
+func slsaBuildSteps(steps []*v1alpha1.BuildStep) []slsaBuildStep {
+	var slsaSteps []slsaBuildStep
+	for _, step := range steps {
+		slsaSteps = append(slsaSteps, slsaBuildStep{
+			Id:         step.Id,
+			Name:       step.Name,
+			Args:       step.Args,
+			WorkingDir: step.Dir,
+			WaitFor:    step.WaitFor,
+		})
+	}
+
+	return slsaSteps
+}
+
+func slsaTimestamp(t *timestamppb.Timestamp) string {
+	if !t.IsValid() {
+		return ""
+	}
+
+	return t.AsTime().UTC().Format(time.RFC3339)
+}
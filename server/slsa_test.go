@@ -0,0 +1,213 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/rode/collector-build/config"
+	"github.com/rode/collector-build/proto/v1alpha1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var subjectDigest = strings.Repeat("cd", 32)
+
+var _ = Describe("slsa", func() {
+	var (
+		conf     *config.Config
+		build    *v1alpha1.Build
+		digest   string
+		commitId string
+	)
+
+	BeforeEach(func() {
+		conf = &config.Config{
+			SlsaBuildType: "https://example.com/build@v1",
+		}
+		digest = strings.Repeat("ab", 32)
+		commitId = strings.Repeat("1", 40)
+		build = &v1alpha1.Build{
+			Repository:   "git://github.com/rode/collector-build",
+			CommitId:     commitId,
+			ProvenanceId: "run-1",
+			LogsUri:      "https://ci.example.com/run-1/logs",
+			BuildStart:   timestamppb.New(time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)),
+			BuildEnd:     timestamppb.New(time.Date(2021, 6, 1, 12, 5, 0, 0, time.UTC)),
+			Artifacts: []*v1alpha1.Artifact{
+				{Id: "https://example.com/no-digest"},
+				{Id: "registry.example.com/app@sha256:" + digest, Names: []string{"registry.example.com/app:v1"}},
+			},
+			Materials: []*v1alpha1.Material{
+				{Uri: "pkg:golang/github.com/rode/rode@v0.14.5", Digest: map[string]string{"sha256": digest}},
+			},
+			Steps:        []*v1alpha1.BuildStep{{Id: "build", Name: "go", Args: []string{"build", "./..."}, Env: []string{"GITHUB_TOKEN=secret"}, Dir: "src"}},
+			Builder:      &v1alpha1.Builder{Id: "https://github.com/actions/runner", Version: "2.280.0"},
+			BuildOptions: map[string]string{"GOOS": "linux"},
+			Source: &v1alpha1.Build_Git{
+				Git: &v1alpha1.GitSource{RevisionAlias: &v1alpha1.GitSource_Branch{Branch: "main"}},
+			},
+		}
+	})
+
+	Describe("newSlsaStatement", func() {
+		It("should generate a SLSA v1 statement", func() {
+			conf.SlsaVersion = config.SlsaVersionV1
+
+			statement, err := newSlsaStatement(conf, build)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statement).To(MatchJSON(`{
+				"_type": "https://in-toto.io/Statement/v1",
+				"subject": [{"name": "registry.example.com/app", "digest": {"sha256": "` + digest + `"}}],
+				"predicateType": "https://slsa.dev/provenance/v1",
+				"predicate": {
+					"buildDefinition": {
+						"buildType": "https://example.com/build@v1",
+						"externalParameters": {
+							"source": {
+								"uri": "git+https://github.com/rode/collector-build",
+								"digest": {"gitCommit": "` + commitId + `"},
+								"ref": "refs/heads/main"
+							},
+							"steps": [{"id": "build", "name": "go", "args": ["build", "./..."], "workingDir": "src"}],
+							"buildOptions": {"GOOS": "linux"}
+						},
+						"resolvedDependencies": [
+							{"uri": "git+https://github.com/rode/collector-build", "digest": {"gitCommit": "` + commitId + `"}},
+							{"uri": "pkg:golang/github.com/rode/rode@v0.14.5", "digest": {"sha256": "` + digest + `"}}
+						]
+					},
+					"runDetails": {
+						"builder": {"id": "https://github.com/actions/runner", "version": {"builder": "2.280.0"}},
+						"metadata": {"invocationId": "run-1", "startedOn": "2021-06-01T12:00:00Z", "finishedOn": "2021-06-01T12:05:00Z"},
+						"byproducts": [{"name": "logs", "uri": "https://ci.example.com/run-1/logs"}]
+					}
+				}
+			}`))
+		})
+
+		It("should generate a SLSA v0.2 statement", func() {
+			conf.SlsaVersion = config.SlsaVersionV02
+
+			statement, err := newSlsaStatement(conf, build)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statement).To(MatchJSON(`{
+				"_type": "https://in-toto.io/Statement/v0.1",
+				"subject": [{"name": "registry.example.com/app", "digest": {"sha256": "` + digest + `"}}],
+				"predicateType": "https://slsa.dev/provenance/v0.2",
+				"predicate": {
+					"builder": {"id": "https://github.com/actions/runner"},
+					"buildType": "https://example.com/build@v1",
+					"invocation": {
+						"configSource": {"uri": "git+https://github.com/rode/collector-build", "digest": {"sha1": "` + commitId + `"}},
+						"parameters": {"GOOS": "linux"}
+					},
+					"buildConfig": {"steps": [{"id": "build", "name": "go", "args": ["build", "./..."], "workingDir": "src"}]},
+					"metadata": {
+						"buildInvocationId": "run-1",
+						"buildStartedOn": "2021-06-01T12:00:00Z",
+						"buildFinishedOn": "2021-06-01T12:05:00Z",
+						"completeness": {"parameters": false, "environment": false, "materials": false},
+						"reproducible": false
+					},
+					"materials": [
+						{"uri": "git+https://github.com/rode/collector-build", "digest": {"sha1": "` + commitId + `"}},
+						{"uri": "pkg:golang/github.com/rode/rode@v0.14.5", "digest": {"sha256": "` + digest + `"}}
+					]
+				}
+			}`))
+		})
+
+		It("should default the builder when the build didn't report one", func() {
+			conf.SlsaVersion = config.SlsaVersionV1
+			build.Builder = nil
+
+			statement, err := newSlsaStatement(conf, build)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(statement)).To(ContainSubstring(`"builder":{"id":"https://github.com/rode/collector-build"}`))
+		})
+
+		It("should not generate a statement when SLSA provenance is disabled", func() {
+			conf.SlsaVersion = config.SlsaVersionNone
+
+			Expect(newSlsaStatement(conf, build)).To(BeNil())
+		})
+
+		It("should not generate a statement when no artifacts have digests", func() {
+			conf.SlsaVersion = config.SlsaVersionV1
+			build.Artifacts = build.Artifacts[:1]
+
+			Expect(newSlsaStatement(conf, build)).To(BeNil())
+		})
+	})
+
+	Describe("slsaSubject", func() {
+		DescribeTable("subject names and digests", func(artifact *v1alpha1.Artifact, expectedName string, expectedDigest *v1alpha1.Digest) {
+			name, digest := slsaSubject(artifact)
+
+			Expect(name).To(Equal(expectedName))
+			Expect(digest).To(Equal(expectedDigest))
+		},
+			Entry("image reference", &v1alpha1.Artifact{Id: "alpine@sha256:" + subjectDigest}, "alpine", &v1alpha1.Digest{Algorithm: "sha256", Hex: subjectDigest}),
+			Entry("bare digest with a name", &v1alpha1.Artifact{Id: "sha256:" + subjectDigest, Names: []string{"app.tgz"}}, "app.tgz", &v1alpha1.Digest{Algorithm: "sha256", Hex: subjectDigest}),
+			Entry("bare digest", &v1alpha1.Artifact{Id: "sha256:" + subjectDigest}, "sha256:"+subjectDigest, &v1alpha1.Digest{Algorithm: "sha256", Hex: subjectDigest}),
+			Entry("generic file", &v1alpha1.Artifact{Id: "file://sha256:" + subjectDigest + ":dist/app.tgz"}, "dist/app.tgz", &v1alpha1.Digest{Algorithm: "sha256", Hex: subjectDigest}),
+			Entry("no digest", &v1alpha1.Artifact{Id: "npm://left-pad:1.3.0"}, "npm://left-pad:1.3.0", nil),
+		)
+	})
+
+	Describe("slsaSource", func() {
+		It("should use the archive uri and digest", func() {
+			build.Source = &v1alpha1.Build_Archive{
+				Archive: &v1alpha1.ArchiveSource{Uri: "https://example.com/src.tgz", Digest: &v1alpha1.Digest{Algorithm: "sha256", Hex: digest}},
+			}
+
+			uri, sourceDigest, ref := slsaSource(build, config.SlsaVersionV1)
+
+			Expect(uri).To(Equal("https://example.com/src.tgz"))
+			Expect(sourceDigest).To(Equal(map[string]string{"sha256": digest}))
+			Expect(ref).To(BeEmpty())
+		})
+
+		It("should use the cloud repo clone url and alias", func() {
+			build.CommitId = ""
+			build.Source = &v1alpha1.Build_CloudRepo{
+				CloudRepo: &v1alpha1.CloudRepoSource{ProjectId: "acme", RepoName: "app", AliasName: "main"},
+			}
+
+			uri, sourceDigest, ref := slsaSource(build, config.SlsaVersionV1)
+
+			Expect(uri).To(Equal("git+https://source.developers.google.com/p/acme/r/app"))
+			Expect(sourceDigest).To(BeNil())
+			Expect(ref).To(Equal("main"))
+		})
+	})
+
+	DescribeTable("gitCommitDigest", func(commitId string, version config.SlsaVersion, expected map[string]string) {
+		Expect(gitCommitDigest(commitId, version)).To(Equal(expected))
+	},
+		Entry("SLSA v1", strings.Repeat("A", 40), config.SlsaVersionV1, map[string]string{"gitCommit": strings.Repeat("a", 40)}),
+		Entry("SLSA v0.2 sha1", strings.Repeat("A", 40), config.SlsaVersionV02, map[string]string{"sha1": strings.Repeat("a", 40)}),
+		Entry("SLSA v0.2 sha256", strings.Repeat("b", 64), config.SlsaVersionV02, map[string]string{"sha256": strings.Repeat("b", 64)}),
+		Entry("SLSA v0.2 abbreviated", "abc123", config.SlsaVersionV02, map[string]string{"gitCommit": "abc123"}),
+		Entry("empty", "", config.SlsaVersionV1, nil),
+	)
+})
@@ -0,0 +1,282 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/rode/collector-build/proto/v1alpha1"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/provenance_go_proto"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/source_go_proto"
+)
+
+// Grafeas source contexts don't have fields for everything the collector accepts, the rest is recorded in the labels
+const (
+	branchSourceLabel    = "branch"
+	tagSourceLabel       = "tag"
+	refSourceLabel       = "ref"
+	changeSourceLabel    = "change"
+	patchsetSourceLabel  = "patchset"
+	aliasKindSourceLabel = "alias_kind"
+	aliasNameSourceLabel = "alias_name"
+
+	archiveResourceUriPrefix = "file://"
+)
+
+var (
+	cloudRepoAliasKinds = map[v1alpha1.CloudRepoSource_AliasKind]source_go_proto.AliasContext_Kind{
+		v1alpha1.CloudRepoSource_ALIAS_KIND_UNSPECIFIED: source_go_proto.AliasContext_KIND_UNSPECIFIED,
+		v1alpha1.CloudRepoSource_FIXED:                  source_go_proto.AliasContext_FIXED,
+		v1alpha1.CloudRepoSource_MOVABLE:                source_go_proto.AliasContext_MOVABLE,
+		v1alpha1.CloudRepoSource_OTHER:                  source_go_proto.AliasContext_OTHER,
+	}
+)
+
+func validateSource(request *v1alpha1.CreateBuildRequest) error {
+	switch source := request.Source.(type) {
+	case *v1alpha1.CreateBuildRequest_Gerrit:
+		if _, err := url.ParseRequestURI(source.Gerrit.HostUri); err != nil {
+			return newFieldError("gerrit.host_uri", "invalid gerrit host uri: %s", err)
+		}
+
+		if len(source.Gerrit.Project) == 0 {
+			return newFieldError("gerrit.project", "no gerrit project specified")
+		}
+
+		if len(request.CommitId) == 0 {
+			return newFieldError("commit_id", "no commit ID specified")
+		}
+	case *v1alpha1.CreateBuildRequest_CloudRepo:
+		if len(source.CloudRepo.ProjectId) == 0 || len(source.CloudRepo.RepoName) == 0 {
+			return newFieldError("cloud_repo", "cloud repo project id and repo name must be specified")
+		}
+
+		if len(request.CommitId) == 0 && len(source.CloudRepo.AliasName) == 0 {
+			return newFieldError("commit_id", "commit ID or cloud repo alias must be specified")
+		}
+	case *v1alpha1.CreateBuildRequest_Archive:
+		if len(source.Archive.Uri) == 0 {
+			return newFieldError("archive.uri", "no archive uri specified")
+		}
+
+		if source.Archive.Digest == nil {
+			return newFieldError("archive.digest", "no archive digest specified")
+		}
+
+		digest, err := normalizeDigest(source.Archive.Digest.Algorithm, source.Archive.Digest.Hex)
+		if err != nil {
+			return newFieldError("archive.digest", "invalid archive digest: %s", err)
+		}
+
+		if !strings.HasPrefix(digest, "sha256:") {
+			return newFieldError("archive.digest", "archive digest must be sha256")
+		}
+	default:
+		if len(request.Repository) == 0 {
+			return newFieldError("repository", "no repository specified")
+		}
+
+		if len(request.CommitId) == 0 {
+			return newFieldError("commit_id", "no commit ID specified")
+		}
+	}
+
+	return nil
+}
+
+// mapRequestToSource returns the resource uri and source provenance for the source of a build. Builds from Git, Gerrit
+// and cloud repos use Rode's Git resource uri format, git://host/path@revision, and archives use the file format,
+// file://sha256:<hex>:<uri>. The request must already be validated, an error is only returned if the repository url
+// can't be parsed.
+func mapRequestToSource(request *v1alpha1.CreateBuildRequest) (string, *provenance_go_proto.Source, error) {
+	switch source := request.Source.(type) {
+	case *v1alpha1.CreateBuildRequest_Gerrit:
+		repositoryUri, err := buildRepositoryResourceUri(strings.TrimRight(source.Gerrit.HostUri, "/") + "/" + source.Gerrit.Project)
+		if err != nil {
+			return "", nil, fieldErrorFrom("gerrit.host_uri", err)
+		}
+
+		return fmt.Sprintf("%s@%s", repositoryUri, request.CommitId), &provenance_go_proto.Source{
+			Context: &source_go_proto.SourceContext{
+				Context: &source_go_proto.SourceContext_Gerrit{
+					Gerrit: &source_go_proto.GerritSourceContext{
+						HostUri:       source.Gerrit.HostUri,
+						GerritProject: source.Gerrit.Project,
+						Revision: &source_go_proto.GerritSourceContext_RevisionId{
+							RevisionId: request.CommitId,
+						},
+					},
+				},
+				Labels: sourceLabels(changeSourceLabel, source.Gerrit.Change, patchsetSourceLabel, source.Gerrit.Patchset),
+			},
+		}, nil
+	case *v1alpha1.CreateBuildRequest_CloudRepo:
+		cloudRepo := &source_go_proto.CloudRepoSourceContext{
+			RepoId: &source_go_proto.RepoId{
+				Id: &source_go_proto.RepoId_ProjectRepoId{
+					ProjectRepoId: &source_go_proto.ProjectRepoId{
+						ProjectId: source.CloudRepo.ProjectId,
+						RepoName:  source.CloudRepo.RepoName,
+					},
+				},
+			},
+		}
+
+		revision := request.CommitId
+		var labels map[string]string
+		if revision != "" {
+			cloudRepo.Revision = &source_go_proto.CloudRepoSourceContext_RevisionId{RevisionId: revision}
+			if source.CloudRepo.AliasName != "" {
+				labels = sourceLabels(aliasKindSourceLabel, source.CloudRepo.AliasKind.String(), aliasNameSourceLabel, source.CloudRepo.AliasName)
+			}
+		} else {
+			revision = source.CloudRepo.AliasName
+			cloudRepo.Revision = &source_go_proto.CloudRepoSourceContext_AliasContext{
+				AliasContext: &source_go_proto.AliasContext{
+					Kind: cloudRepoAliasKinds[source.CloudRepo.AliasKind],
+					Name: source.CloudRepo.AliasName,
+				},
+			}
+		}
+
+		return fmt.Sprintf("git://%s/%s@%s", source.CloudRepo.ProjectId, source.CloudRepo.RepoName, revision), &provenance_go_proto.Source{
+			Context: &source_go_proto.SourceContext{
+				Context: &source_go_proto.SourceContext_CloudRepo{CloudRepo: cloudRepo},
+				Labels:  labels,
+			},
+		}, nil
+	case *v1alpha1.CreateBuildRequest_Archive:
+		digest := strings.ToLower(source.Archive.Digest.Algorithm + ":" + source.Archive.Digest.Hex)
+
+		return fmt.Sprintf("%s%s:%s", archiveResourceUriPrefix, digest, source.Archive.Uri), &provenance_go_proto.Source{
+			ArtifactStorageSourceUri: source.Archive.Uri,
+		}, nil
+	}
+
+	repositoryUri, err := buildRepositoryResourceUri(request.Repository)
+	if err != nil {
+		return "", nil, fieldErrorFrom("repository", err)
+	}
+
+	var labels map[string]string
+	switch alias := request.GetGit().GetRevisionAlias().(type) {
+	case *v1alpha1.GitSource_Branch:
+		labels = sourceLabels(branchSourceLabel, alias.Branch)
+	case *v1alpha1.GitSource_Tag:
+		labels = sourceLabels(tagSourceLabel, alias.Tag)
+	case *v1alpha1.GitSource_Ref:
+		labels = sourceLabels(refSourceLabel, alias.Ref)
+	}
+
+	return fmt.Sprintf("%s@%s", repositoryUri, request.CommitId), &provenance_go_proto.Source{
+		Context: &source_go_proto.SourceContext{
+			Context: &source_go_proto.SourceContext_Git{
+				Git: &source_go_proto.GitSourceContext{
+					Url:        request.CommitUri,
+					RevisionId: request.CommitId,
+				},
+			},
+			Labels: labels,
+		},
+	}, nil
+}
+
+// mapSourceToBuild sets the repository, commit and source of a build from the occurrence's resource uri and source provenance
+func mapSourceToBuild(resourceUri string, source *provenance_go_proto.Source, build *v1alpha1.Build) {
+	context := source.GetContext()
+	labels := context.GetLabels()
+	revision := ""
+
+	switch c := context.GetContext().(type) {
+	case *source_go_proto.SourceContext_Gerrit:
+		revision = c.Gerrit.GetRevisionId()
+		build.Source = &v1alpha1.Build_Gerrit{
+			Gerrit: &v1alpha1.GerritSource{
+				HostUri:  c.Gerrit.HostUri,
+				Project:  c.Gerrit.GerritProject,
+				Change:   labels[changeSourceLabel],
+				Patchset: labels[patchsetSourceLabel],
+			},
+		}
+		build.CommitId = revision
+	case *source_go_proto.SourceContext_CloudRepo:
+		cloudRepo := &v1alpha1.CloudRepoSource{
+			ProjectId: c.CloudRepo.GetRepoId().GetProjectRepoId().GetProjectId(),
+			RepoName:  c.CloudRepo.GetRepoId().GetProjectRepoId().GetRepoName(),
+			AliasKind: v1alpha1.CloudRepoSource_AliasKind(v1alpha1.CloudRepoSource_AliasKind_value[labels[aliasKindSourceLabel]]),
+			AliasName: labels[aliasNameSourceLabel],
+		}
+
+		if alias := c.CloudRepo.GetAliasContext(); alias != nil {
+			revision = alias.Name
+			cloudRepo.AliasName = alias.Name
+			for kind, aliasKind := range cloudRepoAliasKinds {
+				if aliasKind == alias.Kind {
+					cloudRepo.AliasKind = kind
+				}
+			}
+		} else {
+			revision = c.CloudRepo.GetRevisionId()
+			build.CommitId = revision
+		}
+		build.Source = &v1alpha1.Build_CloudRepo{CloudRepo: cloudRepo}
+	case *source_go_proto.SourceContext_Git:
+		revision = c.Git.GetRevisionId()
+		git := &v1alpha1.GitSource{}
+		switch {
+		case labels[branchSourceLabel] != "":
+			git.RevisionAlias = &v1alpha1.GitSource_Branch{Branch: labels[branchSourceLabel]}
+		case labels[tagSourceLabel] != "":
+			git.RevisionAlias = &v1alpha1.GitSource_Tag{Tag: labels[tagSourceLabel]}
+		case labels[refSourceLabel] != "":
+			git.RevisionAlias = &v1alpha1.GitSource_Ref{Ref: labels[refSourceLabel]}
+		}
+		build.Source = &v1alpha1.Build_Git{Git: git}
+		build.CommitId = revision
+		build.CommitUri = c.Git.GetUrl()
+	default:
+		if source.GetArtifactStorageSourceUri() != "" && strings.HasPrefix(resourceUri, archiveResourceUriPrefix) {
+			pieces := strings.SplitN(strings.TrimPrefix(resourceUri, archiveResourceUriPrefix), ":", 3)
+			archive := &v1alpha1.ArchiveSource{Uri: source.ArtifactStorageSourceUri}
+			if len(pieces) == 3 {
+				archive.Digest = &v1alpha1.Digest{Algorithm: pieces[0], Hex: pieces[1]}
+			}
+			build.Source = &v1alpha1.Build_Archive{Archive: archive}
+
+			return
+		}
+	}
+
+	build.Repository = strings.TrimSuffix(resourceUri, "@"+revision)
+}
+
+// sourceLabels builds a labels map from key value pairs, skipping empty values
+func sourceLabels(keysAndValues ...string) map[string]string {
+	var labels map[string]string
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		if keysAndValues[i+1] == "" {
+			continue
+		}
+
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[keysAndValues[i]] = keysAndValues[i+1]
+	}
+
+	return labels
+}
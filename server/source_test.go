@@ -0,0 +1,249 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/rode/collector-build/proto/v1alpha1"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/source_go_proto"
+)
+
+var _ = Describe("source", func() {
+	var (
+		request *v1alpha1.CreateBuildRequest
+		digest  = strings.Repeat("ab", 32)
+	)
+
+	BeforeEach(func() {
+		request = &v1alpha1.CreateBuildRequest{
+			Repository: "https://github.com/rode/collector-build",
+			CommitId:   "abc123",
+			CommitUri:  "https://github.com/rode/collector-build/commit/abc123",
+		}
+	})
+
+	Describe("validateSource", func() {
+		DescribeTable("invalid sources", func(modify func(*v1alpha1.CreateBuildRequest), expectedError string) {
+			modify(request)
+
+			err := validateSource(request)
+
+			Expect(err).To(MatchError(expectedError))
+		},
+			Entry("git without a repository", func(r *v1alpha1.CreateBuildRequest) {
+				r.Repository = ""
+				r.Source = &v1alpha1.CreateBuildRequest_Git{Git: &v1alpha1.GitSource{}}
+			}, "no repository specified"),
+			Entry("gerrit without a host", func(r *v1alpha1.CreateBuildRequest) {
+				r.Source = &v1alpha1.CreateBuildRequest_Gerrit{Gerrit: &v1alpha1.GerritSource{Project: "rode"}}
+			}, "invalid gerrit host uri: parse \"\": empty url"),
+			Entry("gerrit without a project", func(r *v1alpha1.CreateBuildRequest) {
+				r.Source = &v1alpha1.CreateBuildRequest_Gerrit{Gerrit: &v1alpha1.GerritSource{HostUri: "https://gerrit.example.com"}}
+			}, "no gerrit project specified"),
+			Entry("gerrit without a commit", func(r *v1alpha1.CreateBuildRequest) {
+				r.CommitId = ""
+				r.Source = &v1alpha1.CreateBuildRequest_Gerrit{Gerrit: &v1alpha1.GerritSource{HostUri: "https://gerrit.example.com", Project: "rode"}}
+			}, "no commit ID specified"),
+			Entry("cloud repo without a name", func(r *v1alpha1.CreateBuildRequest) {
+				r.Source = &v1alpha1.CreateBuildRequest_CloudRepo{CloudRepo: &v1alpha1.CloudRepoSource{ProjectId: "rode"}}
+			}, "cloud repo project id and repo name must be specified"),
+			Entry("cloud repo without a revision", func(r *v1alpha1.CreateBuildRequest) {
+				r.CommitId = ""
+				r.Source = &v1alpha1.CreateBuildRequest_CloudRepo{CloudRepo: &v1alpha1.CloudRepoSource{ProjectId: "rode", RepoName: "mirror"}}
+			}, "commit ID or cloud repo alias must be specified"),
+			Entry("archive without a uri", func(r *v1alpha1.CreateBuildRequest) {
+				r.Source = &v1alpha1.CreateBuildRequest_Archive{Archive: &v1alpha1.ArchiveSource{}}
+			}, "no archive uri specified"),
+			Entry("archive without a digest", func(r *v1alpha1.CreateBuildRequest) {
+				r.Source = &v1alpha1.CreateBuildRequest_Archive{Archive: &v1alpha1.ArchiveSource{Uri: "https://example.com/src.tgz"}}
+			}, "no archive digest specified"),
+			Entry("archive with a sha1 digest", func(r *v1alpha1.CreateBuildRequest) {
+				r.Source = &v1alpha1.CreateBuildRequest_Archive{Archive: &v1alpha1.ArchiveSource{
+					Uri:    "https://example.com/src.tgz",
+					Digest: &v1alpha1.Digest{Algorithm: "sha1", Hex: strings.Repeat("ab", 20)},
+				}}
+			}, "archive digest must be sha256"),
+		)
+
+		It("should allow archives without a repository or commit", func() {
+			request.Repository = ""
+			request.CommitId = ""
+			request.Source = &v1alpha1.CreateBuildRequest_Archive{Archive: &v1alpha1.ArchiveSource{
+				Uri:    "https://example.com/src.tgz",
+				Digest: &v1alpha1.Digest{Algorithm: "sha256", Hex: digest},
+			}}
+
+			Expect(validateSource(request)).To(Succeed())
+		})
+	})
+
+	Describe("mapping", func() {
+		var (
+			actualResourceUri string
+			actualBuild       *v1alpha1.Build
+			actualError       error
+			actualContext     *source_go_proto.SourceContext
+		)
+
+		JustBeforeEach(func() {
+			resourceUri, source, err := mapRequestToSource(request)
+			actualResourceUri = resourceUri
+			actualError = err
+			actualContext = source.GetContext()
+
+			actualBuild = &v1alpha1.Build{}
+			mapSourceToBuild(resourceUri, source, actualBuild)
+		})
+
+		When("no source is specified", func() {
+			It("should record the build as coming from the git repository", func() {
+				Expect(actualError).NotTo(HaveOccurred())
+				Expect(actualResourceUri).To(Equal("git://github.com/rode/collector-build@abc123"))
+				Expect(actualContext.GetGit().RevisionId).To(Equal("abc123"))
+				Expect(actualContext.GetGit().Url).To(Equal(request.CommitUri))
+			})
+
+			It("should map back to the repository and commit", func() {
+				Expect(actualBuild.Repository).To(Equal("git://github.com/rode/collector-build"))
+				Expect(actualBuild.CommitId).To(Equal("abc123"))
+				Expect(actualBuild.CommitUri).To(Equal(request.CommitUri))
+			})
+		})
+
+		When("the git source has a branch", func() {
+			BeforeEach(func() {
+				request.Source = &v1alpha1.CreateBuildRequest_Git{Git: &v1alpha1.GitSource{
+					RevisionAlias: &v1alpha1.GitSource_Branch{Branch: "main"},
+				}}
+			})
+
+			It("should label the source context with the branch", func() {
+				Expect(actualContext.Labels).To(Equal(map[string]string{"branch": "main"}))
+			})
+
+			It("should map back to the branch", func() {
+				Expect(actualBuild.GetGit().GetBranch()).To(Equal("main"))
+			})
+		})
+
+		When("the repository is not a valid url", func() {
+			BeforeEach(func() {
+				request.Repository = "collector-build"
+			})
+
+			It("should return an error", func() {
+				Expect(actualError).To(HaveOccurred())
+			})
+		})
+
+		When("the source is a gerrit change", func() {
+			BeforeEach(func() {
+				request.Source = &v1alpha1.CreateBuildRequest_Gerrit{Gerrit: &v1alpha1.GerritSource{
+					HostUri:  "https://gerrit.example.com",
+					Project:  "platform/build",
+					Change:   "1234",
+					Patchset: "2",
+				}}
+			})
+
+			It("should record a gerrit source context", func() {
+				Expect(actualResourceUri).To(Equal("git://gerrit.example.com/platform/build@abc123"))
+				Expect(actualContext.GetGerrit().HostUri).To(Equal("https://gerrit.example.com"))
+				Expect(actualContext.GetGerrit().GerritProject).To(Equal("platform/build"))
+				Expect(actualContext.GetGerrit().GetRevisionId()).To(Equal("abc123"))
+				Expect(actualContext.Labels).To(Equal(map[string]string{"change": "1234", "patchset": "2"}))
+			})
+
+			It("should map back to the gerrit source", func() {
+				Expect(actualBuild.Repository).To(Equal("git://gerrit.example.com/platform/build"))
+				Expect(actualBuild.CommitId).To(Equal("abc123"))
+				Expect(actualBuild.GetGerrit()).To(Equal(request.GetGerrit()))
+			})
+		})
+
+		When("the source is a cloud repo alias", func() {
+			BeforeEach(func() {
+				request.CommitId = ""
+				request.Source = &v1alpha1.CreateBuildRequest_CloudRepo{CloudRepo: &v1alpha1.CloudRepoSource{
+					ProjectId: "platform",
+					RepoName:  "hg-mirror",
+					AliasKind: v1alpha1.CloudRepoSource_MOVABLE,
+					AliasName: "default",
+				}}
+			})
+
+			It("should record a cloud repo source context with the alias", func() {
+				Expect(actualResourceUri).To(Equal("git://platform/hg-mirror@default"))
+				Expect(actualContext.GetCloudRepo().GetRepoId().GetProjectRepoId().ProjectId).To(Equal("platform"))
+				Expect(actualContext.GetCloudRepo().GetRepoId().GetProjectRepoId().RepoName).To(Equal("hg-mirror"))
+				Expect(actualContext.GetCloudRepo().GetAliasContext().Kind).To(Equal(source_go_proto.AliasContext_MOVABLE))
+				Expect(actualContext.GetCloudRepo().GetAliasContext().Name).To(Equal("default"))
+			})
+
+			It("should map back to the cloud repo source", func() {
+				Expect(actualBuild.Repository).To(Equal("git://platform/hg-mirror"))
+				Expect(actualBuild.CommitId).To(BeEmpty())
+				Expect(actualBuild.GetCloudRepo()).To(Equal(request.GetCloudRepo()))
+			})
+		})
+
+		When("the source is a cloud repo with a commit and an alias", func() {
+			BeforeEach(func() {
+				request.Source = &v1alpha1.CreateBuildRequest_CloudRepo{CloudRepo: &v1alpha1.CloudRepoSource{
+					ProjectId: "platform",
+					RepoName:  "hg-mirror",
+					AliasKind: v1alpha1.CloudRepoSource_FIXED,
+					AliasName: "v1.0.0",
+				}}
+			})
+
+			It("should use the commit as the revision", func() {
+				Expect(actualResourceUri).To(Equal("git://platform/hg-mirror@abc123"))
+				Expect(actualContext.GetCloudRepo().GetRevisionId()).To(Equal("abc123"))
+			})
+
+			It("should map back to the cloud repo source", func() {
+				Expect(actualBuild.CommitId).To(Equal("abc123"))
+				Expect(actualBuild.GetCloudRepo()).To(Equal(request.GetCloudRepo()))
+			})
+		})
+
+		When("the source is an archive", func() {
+			BeforeEach(func() {
+				request.Repository = ""
+				request.CommitId = ""
+				request.Source = &v1alpha1.CreateBuildRequest_Archive{Archive: &v1alpha1.ArchiveSource{
+					Uri:    "https://example.com/src.tgz",
+					Digest: &v1alpha1.Digest{Algorithm: "SHA256", Hex: strings.ToUpper(digest)},
+				}}
+			})
+
+			It("should record the archive location with a file resource uri", func() {
+				Expect(actualResourceUri).To(Equal("file://sha256:" + digest + ":https://example.com/src.tgz"))
+				Expect(actualContext).To(BeNil())
+			})
+
+			It("should map back to the archive source", func() {
+				Expect(actualBuild.Repository).To(BeEmpty())
+				Expect(actualBuild.GetArchive().Uri).To(Equal("https://example.com/src.tgz"))
+				Expect(actualBuild.GetArchive().Digest).To(Equal(&v1alpha1.Digest{Algorithm: "sha256", Hex: digest}))
+			})
+		})
+	})
+})
@@ -0,0 +1,181 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rode/collector-build/proto/v1alpha1"
+	"github.com/rode/rode/protodeps/grafeas/proto/v1beta1/provenance_go_proto"
+)
+
+const (
+	builderIdBuildOption = "builder_id"
+	// stepStartWaitFor can be used in a step's wait_for to mark that it ran as soon as the build started
+	stepStartWaitFor = "-"
+)
+
+// reservedBuildOptions are build option keys used by the collector itself to record data
+// that BuildProvenance has no dedicated field for.
+var reservedBuildOptions = []string{
+	idempotencyKeyBuildOption,
+	builderIdBuildOption,
+	buildStartAdjustmentBuildOption,
+	buildEndAdjustmentBuildOption,
+	provenanceKeyIdBuildOption,
+	signatureBuildOption,
+	signatureKeyIdBuildOption,
+	sbomOccurrenceIdsBuildOption,
+	ingestedProvenanceBuildOption,
+}
+
+func validateBuildSteps(steps []*v1alpha1.BuildStep) error {
+	stepIds := map[string]bool{}
+	for i, step := range steps {
+		if len(step.Name) == 0 {
+			return newFieldError(fmt.Sprintf("steps[%d].name", i), "build step %d must specify a name", i)
+		}
+
+		for _, env := range step.Env {
+			if !strings.Contains(env, "=") {
+				return newFieldError(fmt.Sprintf("steps[%d].env", i), "build step %d has invalid environment variable %q, expected KEY=VALUE", i, env)
+			}
+		}
+
+		for _, waitFor := range step.WaitFor {
+			if waitFor != stepStartWaitFor && !stepIds[waitFor] {
+				return newFieldError(fmt.Sprintf("steps[%d].wait_for", i), "build step %d waits for unknown step %q", i, waitFor)
+			}
+		}
+
+		if step.Id == "" {
+			continue
+		}
+
+		if step.Id == stepStartWaitFor || stepIds[step.Id] {
+			return newFieldError(fmt.Sprintf("steps[%d].id", i), "build step %d has invalid or duplicate id %q", i, step.Id)
+		}
+		stepIds[step.Id] = true
+	}
+
+	return nil
+}
+
+func validateBuildOptions(buildOptions map[string]string) error {
+	for _, key := range reservedBuildOptions {
+		if _, ok := buildOptions[key]; ok {
+			return newFieldError(fmt.Sprintf("build_options[%s]", key), "build option %s is reserved", key)
+		}
+	}
+
+	return nil
+}
+
+func mapBuildStepsToCommands(steps []*v1alpha1.BuildStep) []*provenance_go_proto.Command {
+	var commands []*provenance_go_proto.Command
+	for _, step := range steps {
+		commands = append(commands, &provenance_go_proto.Command{
+			Id:      step.Id,
+			Name:    step.Name,
+			Args:    step.Args,
+			Env:     step.Env,
+			Dir:     step.Dir,
+			WaitFor: step.WaitFor,
+		})
+	}
+
+	return commands
+}
+
+func mapCommandsToBuildSteps(commands []*provenance_go_proto.Command) []*v1alpha1.BuildStep {
+	var steps []*v1alpha1.BuildStep
+	for _, command := range commands {
+		steps = append(steps, &v1alpha1.BuildStep{
+			Id:      command.Id,
+			Name:    command.Name,
+			Args:    command.Args,
+			Env:     command.Env,
+			Dir:     command.Dir,
+			WaitFor: command.WaitFor,
+		})
+	}
+
+	return steps
+}
+
+// mapRequestToBuildOptions combines the caller's build options with the ones the collector records: the idempotency
+// key, the builder id, since BuildProvenance only has a field for the builder version, and any other reserved options.
+func mapRequestToBuildOptions(request *v1alpha1.CreateBuildRequest, reservedOptions map[string]string) map[string]string {
+	buildOptions := map[string]string{}
+	for key, value := range request.BuildOptions {
+		buildOptions[key] = value
+	}
+
+	for key, value := range reservedOptions {
+		buildOptions[key] = value
+	}
+
+	if key := getIdempotencyKey(request); key != "" {
+		buildOptions[idempotencyKeyBuildOption] = key
+	}
+
+	if builderId := request.GetBuilder().GetId(); builderId != "" {
+		buildOptions[builderIdBuildOption] = builderId
+	}
+
+	if len(buildOptions) == 0 {
+		return nil
+	}
+
+	return buildOptions
+}
+
+// mapProvenanceToBuilder returns the builder recorded on the provenance and the caller's build options,
+// without the options reserved by the collector.
+func mapProvenanceToBuilder(provenance *provenance_go_proto.BuildProvenance) (*v1alpha1.Builder, map[string]string) {
+	var builder *v1alpha1.Builder
+	builderId := provenance.GetBuildOptions()[builderIdBuildOption]
+	if builderId != "" || provenance.GetBuilderVersion() != "" {
+		builder = &v1alpha1.Builder{
+			Id:      builderId,
+			Version: provenance.GetBuilderVersion(),
+		}
+	}
+
+	var buildOptions map[string]string
+	for key, value := range provenance.GetBuildOptions() {
+		if isReservedBuildOption(key) {
+			continue
+		}
+
+		if buildOptions == nil {
+			buildOptions = map[string]string{}
+		}
+		buildOptions[key] = value
+	}
+
+	return builder, buildOptions
+}
+
+func isReservedBuildOption(key string) bool {
+	for _, reserved := range reservedBuildOptions {
+		if key == reserved {
+			return true
+		}
+	}
+
+	return false
+}
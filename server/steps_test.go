@@ -0,0 +1,69 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/rode/collector-build/proto/v1alpha1"
+)
+
+var _ = Describe("steps", func() {
+	Describe("validateBuildSteps", func() {
+		DescribeTable("invalid steps", func(steps []*v1alpha1.BuildStep, expectedField string) {
+			err := validateBuildSteps(steps)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*fieldError).field).To(Equal(expectedField))
+		},
+			Entry("missing name", []*v1alpha1.BuildStep{{Id: "build"}}, "steps[0].name"),
+			Entry("malformed env", []*v1alpha1.BuildStep{{Name: "go", Env: []string{"CGO_ENABLED"}}}, "steps[0].env"),
+			Entry("duplicate id", []*v1alpha1.BuildStep{{Id: "build", Name: "go"}, {Id: "build", Name: "docker"}}, "steps[1].id"),
+			Entry("reserved id", []*v1alpha1.BuildStep{{Id: "-", Name: "go"}}, "steps[0].id"),
+			Entry("unknown wait for", []*v1alpha1.BuildStep{{Name: "go", WaitFor: []string{"test"}}}, "steps[0].wait_for"),
+			Entry("wait for a later step", []*v1alpha1.BuildStep{{Name: "go", WaitFor: []string{"test"}}, {Id: "test", Name: "go"}}, "steps[0].wait_for"),
+		)
+
+		It("should allow steps that wait for the start of the build or an earlier step", func() {
+			steps := []*v1alpha1.BuildStep{
+				{Id: "test", Name: "go", WaitFor: []string{"-"}},
+				{Name: "docker", WaitFor: []string{"test"}},
+			}
+
+			Expect(validateBuildSteps(steps)).To(Succeed())
+		})
+	})
+
+	Describe("mapRequestToBuildOptions", func() {
+		It("should not set build options when there are none", func() {
+			Expect(mapRequestToBuildOptions(&v1alpha1.CreateBuildRequest{}, nil)).To(BeNil())
+		})
+
+		It("should not modify the request's build options", func() {
+			request := &v1alpha1.CreateBuildRequest{
+				IdempotencyKey: "key",
+				BuildOptions:   map[string]string{"machine": "large"},
+			}
+
+			Expect(mapRequestToBuildOptions(request, map[string]string{"build_end_adjustment": "CLAMPED"})).To(Equal(map[string]string{
+				"machine":              "large",
+				"idempotency_key":      "key",
+				"build_end_adjustment": "CLAMPED",
+			}))
+			Expect(request.BuildOptions).To(Equal(map[string]string{"machine": "large"}))
+		})
+	})
+})
@@ -0,0 +1,143 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rode/collector-build/config"
+	"github.com/rode/collector-build/proto/v1alpha1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	buildStartAdjustmentBuildOption = "build_start_adjustment"
+	buildEndAdjustmentBuildOption   = "build_end_adjustment"
+)
+
+// buildTimes are the validated start and end times of a build, along with any adjustments made to them
+type buildTimes struct {
+	start           time.Time
+	end             time.Time
+	startAdjustment v1alpha1.Build_TimestampAdjustment
+	endAdjustment   v1alpha1.Build_TimestampAdjustment
+}
+
+// resolveBuildTimes validates the build start and end times against the current time. A timestamp that's missing,
+// further in the future than the allowed clock skew or, for the end time, before the start or after the maximum
+// build duration is handled according to the configured policy for that field.
+func resolveBuildTimes(conf *config.Config, request *v1alpha1.CreateBuildRequest, now time.Time) (*buildTimes, error) {
+	var latest time.Time
+	if conf.MaxClockSkew > 0 {
+		latest = now.Add(conf.MaxClockSkew)
+	}
+
+	start, startAdjustment, err := resolveTimestamp("build start", "build_start", request.BuildStart, conf.BuildStartPolicy, now, latest)
+	if err != nil {
+		return nil, err
+	}
+
+	end, endAdjustment, err := resolveTimestamp("build end", "build_end", request.BuildEnd, conf.BuildEndPolicy, now, latest)
+	if err != nil {
+		return nil, err
+	}
+
+	earliestEnd := start
+	latestEnd := latest
+	if conf.MaxBuildDuration > 0 && (latestEnd.IsZero() || start.Add(conf.MaxBuildDuration).Before(latestEnd)) {
+		latestEnd = start.Add(conf.MaxBuildDuration)
+	}
+
+	if end.Before(earliestEnd) || (!latestEnd.IsZero() && end.After(latestEnd)) {
+		reason := "build end is before build start"
+		if !end.Before(earliestEnd) {
+			reason = fmt.Sprintf("build took longer than the maximum duration of %s", conf.MaxBuildDuration)
+		}
+
+		switch conf.BuildEndPolicy {
+		case config.TimestampPolicyReject:
+			return nil, newFieldError("build_end", "%s", reason)
+		case config.TimestampPolicyClamp:
+			end, endAdjustment = clampTime(end, earliestEnd, latestEnd), v1alpha1.Build_CLAMPED
+		default:
+			end, endAdjustment = now, v1alpha1.Build_DEFAULTED
+			if now.Before(earliestEnd) || (!latestEnd.IsZero() && now.After(latestEnd)) {
+				end, endAdjustment = clampTime(now, earliestEnd, latestEnd), v1alpha1.Build_CLAMPED
+			}
+		}
+	}
+
+	return &buildTimes{
+		start:           start,
+		end:             end,
+		startAdjustment: startAdjustment,
+		endAdjustment:   endAdjustment,
+	}, nil
+}
+
+func resolveTimestamp(name, field string, t *timestamppb.Timestamp, policy config.TimestampPolicy, now, latest time.Time) (time.Time, v1alpha1.Build_TimestampAdjustment, error) {
+	if !t.IsValid() {
+		if policy == config.TimestampPolicyReject {
+			return time.Time{}, 0, newFieldError(field, "%s is missing or invalid", name)
+		}
+
+		return now, v1alpha1.Build_DEFAULTED, nil
+	}
+
+	value := t.AsTime()
+	if !latest.IsZero() && value.After(latest) {
+		switch policy {
+		case config.TimestampPolicyReject:
+			return time.Time{}, 0, newFieldError(field, "%s is more than %s in the future", name, latest.Sub(now))
+		case config.TimestampPolicyClamp:
+			return latest, v1alpha1.Build_CLAMPED, nil
+		default:
+			return now, v1alpha1.Build_DEFAULTED, nil
+		}
+	}
+
+	return value, v1alpha1.Build_TIMESTAMP_ADJUSTMENT_UNSPECIFIED, nil
+}
+
+func clampTime(t, earliest, latest time.Time) time.Time {
+	if t.Before(earliest) {
+		return earliest
+	}
+
+	if !latest.IsZero() && t.After(latest) {
+		return latest
+	}
+
+	return t
+}
+
+// buildOptions records the adjustments made to the build times as reserved build options
+func (b *buildTimes) buildOptions() map[string]string {
+	buildOptions := map[string]string{}
+	if b.startAdjustment != v1alpha1.Build_TIMESTAMP_ADJUSTMENT_UNSPECIFIED {
+		buildOptions[buildStartAdjustmentBuildOption] = b.startAdjustment.String()
+	}
+
+	if b.endAdjustment != v1alpha1.Build_TIMESTAMP_ADJUSTMENT_UNSPECIFIED {
+		buildOptions[buildEndAdjustmentBuildOption] = b.endAdjustment.String()
+	}
+
+	return buildOptions
+}
+
+func parseTimestampAdjustment(value string) v1alpha1.Build_TimestampAdjustment {
+	return v1alpha1.Build_TimestampAdjustment(v1alpha1.Build_TimestampAdjustment_value[value])
+}
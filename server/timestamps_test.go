@@ -0,0 +1,147 @@
+// Copyright 2021 The Rode Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/rode/collector-build/config"
+	"github.com/rode/collector-build/proto/v1alpha1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ = Describe("timestamps", func() {
+	var (
+		now  = time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+		conf *config.Config
+	)
+
+	at := func(offset time.Duration) *timestamppb.Timestamp {
+		return timestamppb.New(now.Add(offset))
+	}
+
+	BeforeEach(func() {
+		conf = &config.Config{
+			BuildStartPolicy: config.TimestampPolicyDefault,
+			BuildEndPolicy:   config.TimestampPolicyDefault,
+			MaxClockSkew:     time.Minute,
+			MaxBuildDuration: time.Hour,
+		}
+	})
+
+	type expectedTimes struct {
+		start           time.Duration
+		end             time.Duration
+		startAdjustment v1alpha1.Build_TimestampAdjustment
+		endAdjustment   v1alpha1.Build_TimestampAdjustment
+	}
+
+	DescribeTable("resolveBuildTimes",
+		func(startPolicy, endPolicy config.TimestampPolicy, start, end *timestamppb.Timestamp, expected *expectedTimes) {
+			conf.BuildStartPolicy = startPolicy
+			conf.BuildEndPolicy = endPolicy
+
+			actual, err := resolveBuildTimes(conf, &v1alpha1.CreateBuildRequest{BuildStart: start, BuildEnd: end}, now)
+
+			if expected == nil {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.start).To(Equal(now.Add(expected.start)))
+			Expect(actual.end).To(Equal(now.Add(expected.end)))
+			Expect(actual.startAdjustment).To(Equal(expected.startAdjustment))
+			Expect(actual.endAdjustment).To(Equal(expected.endAdjustment))
+		},
+		Entry("valid times", config.TimestampPolicyReject, config.TimestampPolicyReject, at(-10*time.Minute), at(-time.Minute), &expectedTimes{
+			start: -10 * time.Minute,
+			end:   -time.Minute,
+		}),
+		Entry("end within the allowed clock skew", config.TimestampPolicyReject, config.TimestampPolicyReject, at(-10*time.Minute), at(30*time.Second), &expectedTimes{
+			start: -10 * time.Minute,
+			end:   30 * time.Second,
+		}),
+
+		Entry("missing start, default", config.TimestampPolicyDefault, config.TimestampPolicyDefault, nil, at(0), &expectedTimes{
+			startAdjustment: v1alpha1.Build_DEFAULTED,
+		}),
+		Entry("missing start, clamp", config.TimestampPolicyClamp, config.TimestampPolicyDefault, nil, at(0), &expectedTimes{
+			startAdjustment: v1alpha1.Build_DEFAULTED,
+		}),
+		Entry("missing start, reject", config.TimestampPolicyReject, config.TimestampPolicyDefault, nil, at(0), nil),
+		Entry("invalid end, reject", config.TimestampPolicyDefault, config.TimestampPolicyReject, at(-time.Minute), &timestamppb.Timestamp{Nanos: -1}, nil),
+
+		Entry("future start, default", config.TimestampPolicyDefault, config.TimestampPolicyDefault, at(time.Hour), at(time.Hour), &expectedTimes{
+			startAdjustment: v1alpha1.Build_DEFAULTED,
+			endAdjustment:   v1alpha1.Build_DEFAULTED,
+		}),
+		Entry("future start, clamp", config.TimestampPolicyClamp, config.TimestampPolicyClamp, at(time.Hour), at(time.Hour), &expectedTimes{
+			start:           time.Minute,
+			end:             time.Minute,
+			startAdjustment: v1alpha1.Build_CLAMPED,
+			endAdjustment:   v1alpha1.Build_CLAMPED,
+		}),
+		Entry("future start, reject", config.TimestampPolicyReject, config.TimestampPolicyDefault, at(time.Hour), at(time.Hour), nil),
+
+		Entry("end before start, default", config.TimestampPolicyReject, config.TimestampPolicyDefault, at(-10*time.Minute), at(-20*time.Minute), &expectedTimes{
+			start:         -10 * time.Minute,
+			endAdjustment: v1alpha1.Build_DEFAULTED,
+		}),
+		Entry("end before start, clamp", config.TimestampPolicyReject, config.TimestampPolicyClamp, at(-10*time.Minute), at(-20*time.Minute), &expectedTimes{
+			start:         -10 * time.Minute,
+			end:           -10 * time.Minute,
+			endAdjustment: v1alpha1.Build_CLAMPED,
+		}),
+		Entry("end before start, reject", config.TimestampPolicyReject, config.TimestampPolicyReject, at(-10*time.Minute), at(-20*time.Minute), nil),
+
+		Entry("build too long, clamp", config.TimestampPolicyReject, config.TimestampPolicyClamp, at(-3*time.Hour), at(-time.Minute), &expectedTimes{
+			start:         -3 * time.Hour,
+			end:           -2 * time.Hour,
+			endAdjustment: v1alpha1.Build_CLAMPED,
+		}),
+		Entry("build too long, default can't fix it", config.TimestampPolicyReject, config.TimestampPolicyDefault, at(-3*time.Hour), at(-time.Minute), &expectedTimes{
+			start:         -3 * time.Hour,
+			end:           -2 * time.Hour,
+			endAdjustment: v1alpha1.Build_CLAMPED,
+		}),
+		Entry("build too long, reject", config.TimestampPolicyReject, config.TimestampPolicyReject, at(-3*time.Hour), at(-time.Minute), nil),
+	)
+
+	It("should not check the clock skew or duration when they're disabled", func() {
+		conf.MaxClockSkew = 0
+		conf.MaxBuildDuration = 0
+		conf.BuildStartPolicy = config.TimestampPolicyReject
+		conf.BuildEndPolicy = config.TimestampPolicyReject
+
+		actual, err := resolveBuildTimes(conf, &v1alpha1.CreateBuildRequest{BuildStart: at(-48 * time.Hour), BuildEnd: at(24 * time.Hour)}, now)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.start).To(Equal(now.Add(-48 * time.Hour)))
+		Expect(actual.end).To(Equal(now.Add(24 * time.Hour)))
+	})
+
+	It("should record adjustments as build options", func() {
+		times := &buildTimes{startAdjustment: v1alpha1.Build_DEFAULTED, endAdjustment: v1alpha1.Build_CLAMPED}
+
+		Expect(times.buildOptions()).To(Equal(map[string]string{
+			"build_start_adjustment": "DEFAULTED",
+			"build_end_adjustment":   "CLAMPED",
+		}))
+	})
+})